@@ -2,4 +2,11 @@ module cb_api_client
 
 go 1.25.3
 
-require gopkg.in/yaml.v3 v3.0.1
+require (
+	golang.org/x/net v0.57.0
+	golang.org/x/sync v0.22.0
+	golang.org/x/time v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/text v0.40.0 // indirect
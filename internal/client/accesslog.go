@@ -0,0 +1,158 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// AccessLogEntry describes one completed HTTP exchange with the API
+type AccessLogEntry struct {
+	Time       time.Time
+	Method     string
+	Path       string
+	StatusCode int // 0 when no response was received
+	Duration   time.Duration
+	Bytes      int // size of the (decompressed) response body
+}
+
+// latencyBuckets are the upper bounds of the access log latency buckets
+var latencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencyBucket returns the bucket the request duration falls in, named after
+// its inclusive upper bound, e.g. "le_250ms", or "gt_10s" beyond the last one.
+// The fixed set of values makes latency histograms easy to build from logs.
+func (e AccessLogEntry) LatencyBucket() string {
+	for _, bound := range latencyBuckets {
+		if e.Duration <= bound {
+			return "le_" + bound.String()
+		}
+	}
+	return "gt_" + latencyBuckets[len(latencyBuckets)-1].String()
+}
+
+// fields returns the entry as alternating key/value pairs in a fixed order
+func (e AccessLogEntry) fields() []interface{} {
+	return []interface{}{
+		"time", e.Time.UTC().Format(time.RFC3339Nano),
+		"method", e.Method,
+		"path", e.Path,
+		"status", e.StatusCode,
+		"duration_ms", e.Duration.Milliseconds(),
+		"latency_bucket", e.LatencyBucket(),
+		"bytes", e.Bytes,
+	}
+}
+
+// AccessLogger receives one entry per HTTP attempt, retries included, whatever
+// the log level. StdLogger implements it.
+type AccessLogger interface {
+	LogAccess(entry AccessLogEntry)
+}
+
+// WithAccessLogger sends access log entries to the given logger. It enables the
+// access log regardless of the AccessLog config setting.
+func WithAccessLogger(logger AccessLogger) Option {
+	return func(c *BaseClient) {
+		c.accessLog = logger
+	}
+}
+
+// defaultAccessLogger returns the access logger used when AccessLog is set
+// without WithAccessLogger: the client's logger if it implements AccessLogger,
+// as StdLogger and SlogLogger do, or an adapter for any other Logger.
+func defaultAccessLogger(logger Logger) AccessLogger {
+	if a, ok := logger.(AccessLogger); ok {
+		return a
+	}
+	return loggerAccessLog{logger}
+}
+
+// loggerAccessLog writes access entries to a Logger without an access method.
+// Entries go out at info level, or at the lowest level the logger reports as
+// enabled when info is filtered out, so they appear whatever the level.
+type loggerAccessLog struct {
+	logger Logger
+}
+
+func (l loggerAccessLog) LogAccess(entry AccessLogEntry) {
+	level := LevelInfo
+	if enabler, ok := l.logger.(interface{ Enabled(LogLevel) bool }); ok {
+		for level < LevelError && !enabler.Enabled(level) {
+			level++
+		}
+	}
+
+	switch level {
+	case LevelInfo:
+		l.logger.Info("access", entry.fields()...)
+	case LevelWarn:
+		l.logger.Warn("access", entry.fields()...)
+	default:
+		l.logger.Error("access", nil, entry.fields()...)
+	}
+}
+
+// LogAccess writes an access log line at info level straight to the slog
+// handler, skipping its level check so entries appear whatever the level.
+func (l *SlogLogger) LogAccess(entry AccessLogEntry) {
+	record := slog.NewRecord(entry.Time, slog.LevelInfo, "access", 0)
+	fields := entry.fields()
+	// The record time already carries the timestamp
+	record.Add(fields[2:]...)
+	l.logger.Handler().Handle(context.Background(), record)
+}
+
+// LogAccess writes an access log line, in the logger's format, independent of
+// its level. Text lines look like
+// "ACCESS: time=... method=GET path=/subscription/getsubscription status=200 duration_ms=84 latency_bucket=le_100ms bytes=512".
+func (l *StdLogger) LogAccess(entry AccessLogEntry) {
+	fields := entry.fields()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.format == FormatJSON {
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		writeJSONField(&buf, "level", "ACCESS", true)
+		for i := 0; i < len(fields); i += 2 {
+			writeJSONField(&buf, fields[i].(string), fields[i+1], false)
+		}
+		buf.WriteString("}\n")
+		l.writer.Write(buf.Bytes())
+		return
+	}
+
+	msg := "ACCESS:"
+	for i := 0; i < len(fields); i += 2 {
+		msg += fmt.Sprintf(" %s=%s", fields[i], formatTextValue(fields[i+1]))
+	}
+	io.WriteString(l.writer, msg+"\n")
+}
+
+func (c *BaseClient) logAccess(method, path string, statusCode int, start time.Time, size int) {
+	if c.accessLog == nil {
+		return
+	}
+	c.accessLog.LogAccess(AccessLogEntry{
+		Time:       start,
+		Method:     method,
+		Path:       path,
+		StatusCode: statusCode,
+		Duration:   time.Since(start),
+		Bytes:      size,
+	})
+}
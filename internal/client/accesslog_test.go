@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyBucket(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "le_50ms"},
+		{50 * time.Millisecond, "le_50ms"},
+		{51 * time.Millisecond, "le_100ms"},
+		{2 * time.Second, "le_2.5s"},
+		{10 * time.Second, "le_10s"},
+		{time.Minute, "gt_10s"},
+	}
+
+	for _, tt := range tests {
+		if got := (AccessLogEntry{Duration: tt.duration}).LatencyBucket(); got != tt.want {
+			t.Errorf("LatencyBucket(%v) = %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
+
+// levelLogger is a Logger that drops everything below its level
+type levelLogger struct {
+	recordingLogger
+	level LogLevel
+}
+
+func (l *levelLogger) Enabled(level LogLevel) bool { return level >= l.level }
+
+func (l *levelLogger) Info(message string, fields ...interface{}) {
+	if l.Enabled(LevelInfo) {
+		l.recordingLogger.Info(message, fields...)
+	}
+}
+
+func (l *levelLogger) Warn(message string, fields ...interface{}) {
+	if l.Enabled(LevelWarn) {
+		l.recordingLogger.Warn(message, fields...)
+	}
+}
+
+func withAccessLog(c *BaseClient) { c.config.AccessLog = true }
+
+func TestAccessLogIgnoresLevel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("StdLogger", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewWriterLogger(LevelError, &buf)
+		c := newTestClient(t, jsonHandler(http.StatusOK, `{"id":"S1"}`), WithLogger(logger), withAccessLog)
+
+		c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+		if out := buf.String(); !strings.Contains(out, "ACCESS:") || !strings.Contains(out, "latency_bucket=le_") {
+			t.Errorf("log = %q, want an access line with a latency bucket", out)
+		}
+	})
+
+	t.Run("SlogLogger", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError})))
+		c := newTestClient(t, jsonHandler(http.StatusOK, `{"id":"S1"}`), WithLogger(logger), withAccessLog)
+
+		c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+		if out := buf.String(); !strings.Contains(out, "msg=access") || !strings.Contains(out, "status=200") {
+			t.Errorf("log = %q, want an access line", out)
+		}
+	})
+
+	t.Run("other Logger", func(t *testing.T) {
+		logger := &levelLogger{level: LevelWarn}
+		c := newTestClient(t, jsonHandler(http.StatusOK, `{"id":"S1"}`), WithLogger(logger), withAccessLog)
+
+		c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+		if out := logger.output(); !strings.Contains(out, "WARN access") {
+			t.Errorf("log = %q, want an access line at the lowest enabled level", out)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Auth modes for CleverbridgeConfig.AuthMode
+const (
+	AuthModeBasic  = "basic"
+	AuthModeOAuth2 = "oauth2"
+)
+
+// tokenExpiryMargin refreshes OAuth2 tokens this long before they expire, so a
+// token does not run out while a request is in flight
+const tokenExpiryMargin = 30 * time.Second
+
+// defaultTokenLifetime is assumed for OAuth2 tokens whose response carries no
+// usable expires_in. If the token runs out earlier, the 401 it gets triggers a
+// refresh through invalidate.
+const defaultTokenLifetime = time.Hour
+
+// authStrategy produces the Authorization header for outgoing requests
+type authStrategy interface {
+	// authorization returns the Authorization header value
+	authorization(ctx context.Context) (string, error)
+	// invalidate discards header after the API rejected it. It reports whether a
+	// retry with fresh credentials can succeed.
+	invalidate(header string) bool
+}
+
+func newAuthStrategy(cfg *CleverbridgeConfig, httpClient *http.Client, clock Clock) (authStrategy, error) {
+	switch strings.ToLower(cfg.AuthMode) {
+	case "", AuthModeBasic:
+		return &basicAuth{clientID: cfg.ClientID, clientSecret: cfg.ClientSecret}, nil
+	case AuthModeOAuth2:
+		if cfg.TokenURL == "" {
+			return nil, fmt.Errorf("token_url is required for auth_mode %q", AuthModeOAuth2)
+		}
+		return &oauth2Auth{
+			httpClient:   httpClient,
+			clock:        clock,
+			tokenURL:     cfg.TokenURL,
+			clientID:     cfg.ClientID,
+			clientSecret: cfg.ClientSecret,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid auth_mode %q, expected %q or %q", cfg.AuthMode, AuthModeBasic, AuthModeOAuth2)
+	}
+}
+
+// basicAuth sends the client credentials with every request
+type basicAuth struct {
+	clientID     string
+	clientSecret string
+}
+
+func (a *basicAuth) authorization(ctx context.Context) (string, error) {
+	return "Basic " + basicCredentials(a.clientID, a.clientSecret), nil
+}
+
+func (a *basicAuth) invalidate(header string) bool {
+	return false
+}
+
+// oauth2Auth fetches a bearer token with the client-credentials grant and
+// caches it until shortly before it expires. It is safe for concurrent use;
+// concurrent requests share a single token refresh.
+type oauth2Auth struct {
+	httpClient   *http.Client
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	clock        Clock
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func (a *oauth2Auth) authorization(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && a.clock.Now().Before(a.expires.Add(-tokenExpiryMargin)) {
+		return "Bearer " + a.token, nil
+	}
+
+	token, err := a.fetchToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	lifetime := defaultTokenLifetime
+	if token.ExpiresIn > 0 {
+		lifetime = time.Duration(token.ExpiresIn) * time.Second
+	}
+	a.token = token.AccessToken
+	a.expires = a.clock.Now().Add(lifetime)
+	return "Bearer " + a.token, nil
+}
+
+func (a *oauth2Auth) invalidate(header string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Another request may already have refreshed the token
+	if header == "Bearer "+a.token {
+		a.token = ""
+	}
+	return true
+}
+
+func (a *oauth2Auth) fetchToken(ctx context.Context) (*tokenResponse, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Authorization", "Basic "+basicCredentials(a.clientID, a.clientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("token request failed: %w", parseAPIError(resp.StatusCode, body))
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+	return &token, nil
+}
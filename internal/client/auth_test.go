@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// withOAuth2 switches the test client to OAuth2 with the token endpoint served
+// by the test server at /oauth/token
+func withOAuth2(c *BaseClient) {
+	c.config.AuthMode = AuthModeOAuth2
+	c.config.TokenURL = c.config.BaseURL + "/oauth/token"
+}
+
+func TestOAuth2TokenWithoutExpiryIsCached(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing expires_in", `{"access_token":"tok1","token_type":"Bearer"}`},
+		{"zero expires_in", `{"access_token":"tok1","token_type":"Bearer","expires_in":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tokenFetches int
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/oauth/token" {
+					tokenFetches++
+					w.Write([]byte(tt.body))
+					return
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer tok1" {
+					t.Errorf("Authorization = %q, want Bearer tok1", got)
+				}
+				w.Write([]byte(`{"id":"S1"}`))
+			}), withOAuth2)
+
+			for i := 0; i < 3; i++ {
+				if _, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tokenFetches != 1 {
+				t.Errorf("token fetched %d times for 3 requests, want 1", tokenFetches)
+			}
+		})
+	}
+}
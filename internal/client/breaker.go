@@ -0,0 +1,141 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerWindow   = time.Minute
+	defaultBreakerCooldown = 30 * time.Second
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops requests after repeated failures. It opens after
+// threshold consecutive failures within window, rejects requests for cooldown,
+// then lets a single probe through: success closes it, failure opens it again.
+type circuitBreaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	mu           sync.Mutex
+	state        breakerState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probeStarted time.Time
+}
+
+// newCircuitBreaker returns nil, a disabled breaker, when the threshold is not set
+func newCircuitBreaker(cfg *CleverbridgeConfig) *circuitBreaker {
+	if cfg.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+	b := &circuitBreaker{
+		threshold: cfg.CircuitBreakerThreshold,
+		window:    cfg.CircuitBreakerWindow,
+		cooldown:  cfg.CircuitBreakerCooldown,
+	}
+	if b.window <= 0 {
+		b.window = defaultBreakerWindow
+	}
+	if b.cooldown <= 0 {
+		b.cooldown = defaultBreakerCooldown
+	}
+	return b
+}
+
+// allow reports whether a request may be sent now
+func (b *circuitBreaker) allow(now time.Time) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if remaining := b.cooldown - now.Sub(b.openedAt); remaining > 0 {
+			return fmt.Errorf("%w, retry in %s", ErrCircuitOpen, remaining.Round(time.Second))
+		}
+		b.state = breakerHalfOpen
+		b.probeStarted = now
+		return nil
+	case breakerHalfOpen:
+		// A probe that never reported back, e.g. because the caller cancelled it,
+		// is replaced after another cooldown
+		if now.Sub(b.probeStarted) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.probeStarted = now
+		return nil
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request and reports whether
+// this outcome opened it
+func (b *circuitBreaker) record(failed bool, now time.Time) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.state = breakerClosed
+		b.failures = 0
+		return false
+	}
+
+	if b.state == breakerHalfOpen {
+		b.open(now)
+		return true
+	}
+	if b.state == breakerOpen {
+		return false
+	}
+
+	if b.failures == 0 || now.Sub(b.firstFailure) > b.window {
+		b.failures = 0
+		b.firstFailure = now
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.open(now)
+		return true
+	}
+	return false
+}
+
+func (b *circuitBreaker) open(now time.Time) {
+	b.state = breakerOpen
+	b.openedAt = now
+	b.failures = 0
+}
+
+// isOutage reports whether an attempt's outcome points at the API being down.
+// Client errors and rate limiting are answers from a healthy API.
+func isOutage(statusCode int, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch statusCode {
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
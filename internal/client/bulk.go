@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultMaxConcurrency = 8
+
+func (c *BaseClient) maxConcurrency() int {
+	if c.config.MaxConcurrency > 0 {
+		return c.config.MaxConcurrency
+	}
+	return defaultMaxConcurrency
+}
+
+// GetSubscriptions fetches several subscriptions in parallel, each with the
+// given view as in GetSubscription, with at most concurrency requests in flight.
+// A concurrency of 0 or less uses MaxConcurrency from the config, 8 by default.
+// The returned slice keeps the order of ids and holds only the subscriptions
+// that were fetched; failures are combined with errors.Join. No new requests
+// are started once ctx is done.
+func (c *BaseClient) GetSubscriptions(ctx context.Context, ids []string, view SubscriptionView, concurrency int) ([]Subscription, error) {
+	if _, err := view.isCurrent(); err != nil {
+		return nil, err
+	}
+	if concurrency <= 0 {
+		concurrency = c.maxConcurrency()
+	}
+
+	c.log(ctx).Info("Getting subscriptions in bulk",
+		"subscriptions_count", len(ids),
+		"view", string(view),
+		"concurrency", concurrency)
+
+	results := make([]*Subscription, len(ids))
+	errs := make([]error, len(ids))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+launch:
+	for i, id := range ids {
+		select {
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("subscription %s: %w", id, ctx.Err())
+			for j := i + 1; j < len(ids); j++ {
+				errs[j] = fmt.Errorf("subscription %s: %w", ids[j], ctx.Err())
+			}
+			break launch
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			subscription, err := c.GetSubscription(ctx, id, view)
+			if err != nil {
+				errs[i] = fmt.Errorf("subscription %s: %w", id, err)
+				return
+			}
+			results[i] = subscription
+		}(i, id)
+	}
+	wg.Wait()
+
+	subscriptions := make([]Subscription, 0, len(ids))
+	for _, subscription := range results {
+		if subscription != nil {
+			subscriptions = append(subscriptions, *subscription)
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		c.log(ctx).Error("Some subscriptions could not be retrieved", err,
+			"requested_count", len(ids),
+			"retrieved_count", len(subscriptions))
+	} else {
+		c.log(ctx).Info("Successfully retrieved subscriptions in bulk",
+			"subscriptions_count", len(subscriptions))
+	}
+
+	return subscriptions, err
+}
+
+// CancelSubscriptions cancels several subscriptions in parallel, with at most
+// MaxConcurrency requests in flight, and reports failures per subscription id.
+// The map is empty when every cancellation succeeded; ids that could not be
+// started because ctx was done are reported with ctx.Err(). The error return
+// is only set when nothing was attempted, e.g. in read-only mode. Every
+// cancellation carries its own idempotency key, as with CancelSubscription.
+func (c *BaseClient) CancelSubscriptions(ctx context.Context, ids []string, reason string) (map[string]error, error) {
+	if c.config.ReadOnly {
+		return nil, fmt.Errorf("cancel subscriptions: %w", ErrReadOnlyMode)
+	}
+
+	c.log(ctx).Info("Cancelling subscriptions in bulk",
+		"subscriptions_count", len(ids),
+		"concurrency", c.maxConcurrency(),
+		"reason", reason)
+
+	failures := make(map[string]error)
+	var mu sync.Mutex
+	fail := func(id string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures[id] = err
+	}
+
+	sem := make(chan struct{}, c.maxConcurrency())
+	var wg sync.WaitGroup
+	seen := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		select {
+		case <-ctx.Done():
+			fail(id, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if _, err := c.CancelSubscription(ctx, id, reason); err != nil {
+				fail(id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		c.log(ctx).Warn("Some subscriptions could not be cancelled",
+			"requested_count", len(seen),
+			"failed_count", len(failures))
+	} else {
+		c.log(ctx).Info("Successfully cancelled subscriptions in bulk",
+			"subscriptions_count", len(seen))
+	}
+
+	return failures, nil
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCancelSubscriptionsPartialFailure(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]bool{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req cancelSubscriptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		mu.Lock()
+		keys[r.Header.Get("Idempotency-Key")] = true
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.SubscriptionID == "S404" || req.SubscriptionID == "S405" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"subscription not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": req.SubscriptionID, "status": "cancelled"})
+	}))
+
+	ids := []string{"S1", "S404", "S2", "S405", "S3", "S1"}
+	failures, err := c.CancelSubscriptions(context.Background(), ids, "offboarding")
+	if err != nil {
+		t.Fatalf("CancelSubscriptions error: %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("failures = %v, want S404 and S405", failures)
+	}
+	for _, id := range []string{"S404", "S405"} {
+		if !errors.Is(failures[id], ErrNotFound) {
+			t.Errorf("failures[%s] = %v, want ErrNotFound", id, failures[id])
+		}
+	}
+	if len(keys) != 5 || keys[""] {
+		t.Errorf("got %d distinct idempotency keys, want one per distinct id", len(keys))
+	}
+}
+
+func TestCancelSubscriptionsReadOnly(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}), func(c *BaseClient) { c.config.ReadOnly = true })
+
+	failures, err := c.CancelSubscriptions(context.Background(), []string{"S1"}, "")
+	if !errors.Is(err, ErrReadOnlyMode) || failures != nil {
+		t.Errorf("CancelSubscriptions = %v, %v, want ErrReadOnlyMode", failures, err)
+	}
+}
+
+func TestGetSubscriptions(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		if got := r.URL.Query().Get("isCurrent"); got != "true" {
+			t.Errorf("isCurrent = %q, want true for SubscriptionViewCurrent", got)
+		}
+		id := r.URL.Query().Get("subscriptionId")
+		if id == "S404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": id})
+	}))
+
+	ids := []string{"S1", "S2", "S404", "S3", "S4", "S5", "S6"}
+	subscriptions, err := c.GetSubscriptions(context.Background(), ids, SubscriptionViewCurrent, 2)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("error = %v, want it to include ErrNotFound for S404", err)
+	}
+	if got, want := subscriptionIDs(subscriptions), []string{"S1", "S2", "S3", "S4", "S5", "S6"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subscriptions = %v, want %v", got, want)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("%d requests in flight, want at most 2", maxInFlight)
+	}
+}
+
+func TestGetSubscriptionsInvalidView(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+
+	if _, err := c.GetSubscriptions(context.Background(), []string{"S1"}, "latest", 0); err == nil {
+		t.Error("GetSubscriptions with an invalid view succeeded, want an error")
+	}
+}
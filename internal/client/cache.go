@@ -0,0 +1,121 @@
+package client
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache holds successful GET responses for a fixed time
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	response *Response
+	expires  time.Time
+}
+
+// WithCache caches successful GET responses in memory for ttl, keyed by path,
+// query and per-request headers. Responses marked Cache-Control: no-store are
+// never cached. Caching is off unless this option is given; use ClearCache to
+// drop cached entries.
+func WithCache(ttl time.Duration) Option {
+	return func(c *BaseClient) {
+		if ttl <= 0 {
+			c.cache = nil
+			return
+		}
+		c.cache = &responseCache{
+			ttl:     ttl,
+			entries: make(map[string]cacheEntry),
+		}
+	}
+}
+
+// ClearCache drops all cached responses, including those kept for
+// WithConditionalRequests. It is a no-op when neither is enabled.
+func (c *BaseClient) ClearCache() {
+	if c.etags != nil {
+		c.etags.clear()
+	}
+	if c.cache == nil {
+		return
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	c.cache.entries = make(map[string]cacheEntry)
+}
+
+// cacheKey identifies a request by method, URL and caller-supplied headers, so
+// e.g. different tenant headers do not share entries
+func cacheKey(method, fullURL string, headerSets ...map[string]string) string {
+	var headers []string
+	for _, set := range headerSets {
+		for key, value := range set {
+			headers = append(headers, http.CanonicalHeaderKey(key)+":"+value)
+		}
+	}
+	sort.Strings(headers)
+	return method + " " + fullURL + "\n" + strings.Join(headers, "\n")
+}
+
+func (rc *responseCache) get(key string, now time.Time) (*Response, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if now.After(entry.expires) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+	return entry.response.clone(), true
+}
+
+func (rc *responseCache) put(key string, resp *Response, now time.Time) {
+	if noStore(resp.Headers) {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	// Sweep expired entries so the cache doesn't grow without bound
+	for k, entry := range rc.entries {
+		if now.After(entry.expires) {
+			delete(rc.entries, k)
+		}
+	}
+	rc.entries[key] = cacheEntry{
+		response: resp.clone(),
+		expires:  now.Add(rc.ttl),
+	}
+}
+
+// noStore reports whether the response forbids caching
+func noStore(headers http.Header) bool {
+	for _, value := range headers.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Response) clone() *Response {
+	return &Response{
+		StatusCode: r.StatusCode,
+		Body:       append([]byte(nil), r.Body...),
+		Headers:    r.Headers.Clone(),
+		path:       r.path,
+	}
+}
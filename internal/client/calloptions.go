@@ -0,0 +1,132 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CallOption customizes a single API call
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	idempotencyKey  string
+	responseHeaders *http.Header
+	headers         map[string]string
+	expand          []string
+	sort            *subscriptionSort
+	retryUnsafe     bool
+	noRetry         bool
+	timeout         time.Duration
+}
+
+func newCallOptions(opts []CallOption) *callOptions {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithIdempotencyKey sets the Idempotency-Key sent with a request other than GET or HEAD.
+//
+// GET endpoints are idempotent by nature. Every mutating method, such as
+// CancelSubscription, ChangeSubscriptionQuantity or RefundPurchase, generates a
+// fresh key per call and reuses it across retries of that call, so a retry
+// cannot apply the change twice. Supply your own key to deduplicate the same
+// operation across separate calls or processes.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithResponseHeaders stores the headers of the final response in *headers, e.g. to
+// read X-RateLimit-Remaining or the Cleverbridge request id after a typed call.
+// Headers are captured for error responses too.
+func WithResponseHeaders(headers *http.Header) CallOption {
+	return func(o *callOptions) {
+		o.responseHeaders = headers
+	}
+}
+
+// withoutResponseHeaders undoes WithResponseHeaders, for calls that must not
+// write to the caller's headers
+func withoutResponseHeaders() CallOption {
+	return func(o *callOptions) {
+		o.responseHeaders = nil
+	}
+}
+
+// WithHeader adds a header to the request, e.g. a tenant id for multi-account
+// setups. It overrides headers the client sets itself, such as Accept, except for
+// Authorization, which is always derived from the configured credentials and
+// cannot be replaced this way.
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// WithLocale sets Accept-Language for a single request, e.g. to the customer's
+// language, overriding the Locale config setting
+func WithLocale(locale string) CallOption {
+	return WithHeader("Accept-Language", locale)
+}
+
+// WithExpand asks the API to embed related objects, e.g. WithExpand("customer",
+// "product"), in the response. Use it with methods that return the expanded
+// objects, such as GetSubscriptionExpanded.
+func WithExpand(fields ...string) CallOption {
+	return func(o *callOptions) {
+		o.expand = append(o.expand, fields...)
+	}
+}
+
+// WithRetryUnsafe allows a mutating request (anything but GET and HEAD) to be
+// retried on transient failures. Such requests are not retried by default
+// because a request that timed out may still have been applied. The request
+// carries an idempotency key, generated unless set with WithIdempotencyKey, so
+// only opt in for endpoints that honour it.
+func WithRetryUnsafe() CallOption {
+	return func(o *callOptions) {
+		o.retryUnsafe = true
+	}
+}
+
+// WithNoRetry sends a request exactly once, whatever the client's retry settings,
+// so latency-sensitive calls fail fast on transient errors and 5xx responses.
+// It takes precedence over WithRetryUnsafe.
+func WithNoRetry() CallOption {
+	return func(o *callOptions) {
+		o.noRetry = true
+	}
+}
+
+// WithTimeout bounds each HTTP attempt of a single call by d, overriding
+// HTTPTimeout and EndpointTimeouts. A shorter deadline on ctx still applies, as
+// does DefaultRequestTimeout for the call as a whole.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// randReader is the source of idempotency keys, replaced in tests
+var randReader io.Reader = rand.Reader
+
+// newIdempotencyKey returns a random UUID (version 4)
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := io.ReadFull(randReader, b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// CBTime is a time.Time that understands the date formats returned by Cleverbridge:
+// RFC 3339, ISO 8601 without a timezone (interpreted as UTC), and the
+// Microsoft JSON form /Date(1609459200000)/ with an optional +hhmm offset.
+type CBTime struct {
+	time.Time
+}
+
+// msDatePattern matches /Date(milliseconds[+-hhmm])/
+var msDatePattern = regexp.MustCompile(`^/Date\((-?\d+)([+-]\d{4})?\)/$`)
+
+// cbTimeLayouts are tried in order for string dates that aren't in the /Date()/ form
+var cbTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func (t *CBTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date %s: %w", string(data), err)
+	}
+
+	parsed, err := parseCBTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t CBTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
+
+func parseCBTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if match := msDatePattern.FindStringSubmatch(s); match != nil {
+		millis, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q: %w", s, err)
+		}
+		parsed := time.UnixMilli(millis).UTC()
+		if offset := match[2]; offset != "" {
+			hours, _ := strconv.Atoi(offset[1:3])
+			minutes, _ := strconv.Atoi(offset[3:5])
+			seconds := hours*3600 + minutes*60
+			if offset[0] == '-' {
+				seconds = -seconds
+			}
+			parsed = parsed.In(time.FixedZone("", seconds))
+		}
+		return parsed, nil
+	}
+
+	for _, layout := range cbTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date format %q", s)
+}
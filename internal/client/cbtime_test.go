@@ -0,0 +1,49 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCBTimeUnmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{"RFC 3339", `"2021-01-01T12:30:00Z"`, time.Date(2021, 1, 1, 12, 30, 0, 0, time.UTC)},
+		{"RFC 3339 with offset", `"2021-01-01T12:30:00+02:00"`, time.Date(2021, 1, 1, 10, 30, 0, 0, time.UTC)},
+		{"RFC 3339 with fraction", `"2021-01-01T12:30:00.123Z"`, time.Date(2021, 1, 1, 12, 30, 0, 123e6, time.UTC)},
+		{"ISO 8601 without zone", `"2021-01-01T12:30:00"`, time.Date(2021, 1, 1, 12, 30, 0, 0, time.UTC)},
+		{"ISO 8601 without zone with fraction", `"2021-01-01T12:30:00.5"`, time.Date(2021, 1, 1, 12, 30, 0, 5e8, time.UTC)},
+		{"space separated", `"2021-01-01 12:30:00"`, time.Date(2021, 1, 1, 12, 30, 0, 0, time.UTC)},
+		{"date only", `"2021-01-01"`, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Microsoft JSON", `"/Date(1609459200000)/"`, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Microsoft JSON with offset", `"/Date(1609459200000+0200)/"`, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Microsoft JSON negative", `"/Date(-86400000)/"`, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"empty string", `""`, time.Time{}},
+		{"null", `null`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got CBTime
+			if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("Unmarshal(%s) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, got.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestCBTimeUnmarshalInvalid(t *testing.T) {
+	for _, input := range []string{`"yesterday"`, `"2021-13-01"`, `12345`} {
+		var got CBTime
+		if err := json.Unmarshal([]byte(input), &got); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want error", input, got.Time)
+		}
+	}
+}
@@ -1,218 +1,735 @@
-package client
-
-import (
-	"bytes"
-	"context"
-	"encoding/base64"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"time"
-)
-
-func NewAPIClient(config *CleverbridgeConfig) *APIClient {
-	baseClient := BaseClient{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: config.BaseURL,
-		config:  config,
-	}
-
-	return &APIClient{
-		BaseClient: baseClient,
-		logger:     NewLogger(config.Debug, ""),
-	}
-}
-
-func (c *APIClient) getBasicAuth() string {
-	auth := c.config.ClientID + ":" + c.config.ClientSecret
-	return base64.StdEncoding.EncodeToString([]byte(auth))
-}
-
-func (c *APIClient) sendRequest(ctx context.Context, method, path string, queryParams map[string]string, body interface{}) ([]byte, error) {
-	fullURL := c.baseURL + path
-	if queryParams != nil && len(queryParams) > 0 {
-		params := url.Values{}
-		for key, value := range queryParams {
-			params.Add(key, value)
-		}
-		fullURL = fullURL + "?" + params.Encode()
-	}
-
-	c.logger.Info("Sending API request",
-		"method", method,
-		"url", fullURL,
-		"path", path)
-
-	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
-		if err != nil {
-			c.logger.Error("Failed to marshal request body", err,
-				"method", method, "path", path)
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		reqBody = bytes.NewReader(jsonData)
-
-		if c.config.Debug {
-			c.logger.Json(map[string]interface{}{
-				"request_body": string(jsonData),
-				"method":       method,
-				"path":         path,
-			})
-		}
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
-	if err != nil {
-		c.logger.Error("Failed to create HTTP request", err,
-			"method", method, "url", fullURL)
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Basic "+c.getBasicAuth())
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	startTime := time.Now()
-	resp, err := c.httpClient.Do(req)
-	requestDuration := time.Since(startTime)
-
-	if err != nil {
-		c.logger.Error("HTTP request failed", err,
-			"method", method,
-			"url", fullURL,
-			"duration", requestDuration.String())
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.logger.Error("Failed to read response body", err,
-			"method", method,
-			"url", fullURL,
-			"status_code", resp.StatusCode)
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	c.logger.Info("API response received",
-		"method", method,
-		"path", path,
-		"status_code", resp.StatusCode,
-		"duration", requestDuration.String(),
-		"response_size", len(responseBody))
-
-	if c.config.Debug && len(responseBody) > 0 {
-		c.logger.Json(map[string]interface{}{
-			"response_body": string(responseBody),
-			"status_code":   resp.StatusCode,
-			"method":        method,
-			"path":          path,
-		})
-	}
-
-	if resp.StatusCode >= 400 {
-		c.logger.Error("API returned error response", nil,
-			"method", method,
-			"url", fullURL,
-			"status_code", resp.StatusCode,
-			"response", string(responseBody))
-		return nil, fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(responseBody))
-	}
-
-	return responseBody, nil
-}
-func (c *APIClient) GetSubscription(ctx context.Context, subscriptionID, isCurrent string) (*Subscription, error) {
-	c.logger.Info("Getting subscription",
-		"subscription_id", subscriptionID,
-		"is_current", isCurrent)
-
-	queryParams := map[string]string{
-		"subscriptionId": subscriptionID,
-		"isCurrent":      isCurrent,
-	}
-
-	responseBody, err := c.sendRequest(ctx, "GET", "/subscription/getsubscription", queryParams, nil)
-	if err != nil {
-		c.logger.Error("Failed to get subscription", err,
-			"subscription_id", subscriptionID)
-		return nil, fmt.Errorf("failed to get subscription: %w", err)
-	}
-
-	var subscription Subscription
-	if err := json.Unmarshal(responseBody, &subscription); err != nil {
-		c.logger.Error("Failed to parse subscription response", err,
-			"subscription_id", subscriptionID,
-			"response_body", string(responseBody))
-		return nil, fmt.Errorf("failed to parse subscription: %w", err)
-	}
-
-	c.logger.Info("Successfully retrieved subscription",
-		"subscription_id", subscription.ID,
-		"status", subscription.Status,
-		"plan", subscription.Plan)
-
-	return &subscription, nil
-}
-
-func (c *APIClient) GetSubscriptionsByPurchase(ctx context.Context, purchaseID string) ([]Subscription, error) {
-	c.logger.Info("Getting subscriptions by purchase", "purchase_id", purchaseID)
-
-	queryParams := map[string]string{
-		"purchaseId": purchaseID,
-	}
-
-	responseBody, err := c.sendRequest(ctx, "GET", "/subscription/getsubscriptionsbypurchase", queryParams, nil)
-	if err != nil {
-		c.logger.Error("Failed to get subscriptions by purchase", err,
-			"purchase_id", purchaseID)
-		return nil, fmt.Errorf("failed to get subscriptions by purchase: %w", err)
-	}
-
-	var subscriptions []Subscription
-	if err := json.Unmarshal(responseBody, &subscriptions); err != nil {
-		c.logger.Error("Failed to parse subscriptions response", err,
-			"purchase_id", purchaseID,
-			"response_body", string(responseBody))
-		return nil, fmt.Errorf("failed to parse subscriptions: %w", err)
-	}
-
-	c.logger.Info("Successfully retrieved subscriptions by purchase",
-		"purchase_id", purchaseID,
-		"subscriptions_count", len(subscriptions))
-
-	return subscriptions, nil
-}
-
-func (c *APIClient) GetSubscriptionsForCustomer(ctx context.Context, customerID string) ([]Subscription, error) {
-	c.logger.Info("Getting subscriptions for customer", "customer_id", customerID)
-
-	queryParams := map[string]string{
-		"customerId": customerID,
-	}
-
-	responseBody, err := c.sendRequest(ctx, "GET", "/subscription/getsubscriptionsforcustomer", queryParams, nil)
-	if err != nil {
-		c.logger.Error("Failed to get subscriptions for customer", err,
-			"customer_id", customerID)
-		return nil, fmt.Errorf("failed to get subscriptions for customer: %w", err)
-	}
-
-	var subscriptions []Subscription
-	if err := json.Unmarshal(responseBody, &subscriptions); err != nil {
-		c.logger.Error("Failed to parse subscriptions response", err,
-			"customer_id", customerID,
-			"response_body", string(responseBody))
-		return nil, fmt.Errorf("failed to parse subscriptions: %w", err)
-	}
-
-	c.logger.Info("Successfully retrieved subscriptions for customer",
-		"customer_id", customerID,
-		"subscriptions_count", len(subscriptions))
-
-	return subscriptions, nil
-}
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/time/rate"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+const defaultMaxResponseBytes = 32 << 20
+
+// NewBaseClient creates a Cleverbridge API client from the given config.
+// Options are applied after the config and take precedence over its values, so
+// an option such as WithBaseURL may supply a field the config leaves empty. The
+// resulting config is checked with Validate.
+func NewBaseClient(config *CleverbridgeConfig, opts ...Option) (*BaseClient, error) {
+	cfg := *config
+	c := &BaseClient{
+		config: &cfg,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if c.httpClient == nil {
+		transport, err := newTransport(c.config, c.rootCAs)
+		if err != nil {
+			return nil, err
+		}
+		// The timeout is applied per request through the context in sendRequest,
+		// so that a caller-supplied deadline can override it
+		c.httpClient = &http.Client{Transport: transport}
+	}
+
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
+
+	auth, err := newAuthStrategy(c.config, c.httpClient, c.clock)
+	if err != nil {
+		return nil, err
+	}
+	c.auth = auth
+
+	baseURL, err := normalizeBaseURL(c.config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	c.config.BaseURL = baseURL
+	c.config.PathPrefix = normalizePathPrefix(c.config.PathPrefix)
+	// Every request path is relative to the prefix, e.g. /v2/subscription/getsubscription
+	c.baseURL = baseURL + c.config.PathPrefix
+
+	c.limiter = newLimiter(c.config)
+	c.breaker = newCircuitBreaker(c.config)
+
+	sharedLogger := c.logger != nil
+	if c.logger == nil {
+		logger, err := newDefaultLogger(c.config, c.logWriter)
+		if err != nil {
+			return nil, err
+		}
+		c.logger = logger
+	}
+	c.rawLogger = c.logger
+	if c.accessLog == nil && c.config.AccessLog {
+		c.accessLog = defaultAccessLogger(c.rawLogger)
+	}
+	// A logger passed in with WithLogger may be shared by several clients, so
+	// each holds its own reference and Close only releases that
+	if r, ok := c.rawLogger.(interface{ retain() }); ok && sharedLogger {
+		r.retain()
+	}
+	c.logger = newRedactingLogger(c.logger, cfg.ClientSecret, c.getBasicAuth())
+
+	if c.config.InsecureSkipVerify {
+		c.logger.Warn("TLS certificate verification is disabled, use this for local testing only")
+	}
+	if c.config.ReadOnly {
+		c.logger.Warn("Read-only mode is enabled, mutating requests will not be sent")
+	}
+
+	return c, nil
+}
+
+// newLimiter returns the client-side rate limiter, nil when RequestsPerSecond is unset
+func newLimiter(cfg *CleverbridgeConfig) *rate.Limiter {
+	if cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := cfg.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), burst)
+}
+
+// newDefaultLogger builds the StdLogger used when no logger is supplied. It writes
+// to the WithWriter writer, the configured log file (rotated when LogMaxSizeMB is
+// set), or stdout.
+func newDefaultLogger(cfg *CleverbridgeConfig, writer io.Writer) (*StdLogger, error) {
+	var logger *StdLogger
+	switch {
+	case writer != nil:
+		logger = NewWriterLogger(cfg.logLevel(), writer)
+	case cfg.LogFile != "" && cfg.LogMaxSizeMB > 0:
+		var err error
+		logger, err = NewRotatingLogger(cfg.logLevel(), cfg.LogFile, int64(cfg.LogMaxSizeMB)<<20, cfg.LogMaxBackups)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		logger = NewLogger(cfg.logLevel(), cfg.LogFile)
+	}
+
+	if strings.EqualFold(cfg.LogFormat, "json") {
+		logger.SetFormat(FormatJSON)
+	}
+	return logger, nil
+}
+
+// normalizeBaseURL validates that the base URL is an absolute http(s) URL and
+// strips trailing slashes so paths can be appended directly
+func normalizeBaseURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", fmt.Errorf("base URL is required")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL %q: %w", rawURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid base URL %q: scheme must be http or https", rawURL)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: missing host", rawURL)
+	}
+
+	return strings.TrimRight(rawURL, "/"), nil
+}
+
+// defaultMaxLogBodyBytes caps request and response bodies in log output
+const defaultMaxLogBodyBytes = 4096
+
+// logBody redacts a body for logging and truncates it to MaxLogBodyBytes. The
+// body itself is left untouched for decoding.
+func (c *BaseClient) logBody(body []byte) string {
+	logged := redactJSON(body)
+
+	limit := c.config.MaxLogBodyBytes
+	if limit == 0 {
+		limit = defaultMaxLogBodyBytes
+	}
+	if limit < 0 {
+		return logged
+	}
+	return truncate(logged, limit)
+}
+
+// truncate cuts s to at most limit bytes, noting how much was dropped
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	// Cut on a rune boundary so the result stays valid UTF-8
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s...[truncated %d bytes]", s[:cut], len(s)-cut)
+}
+
+// normalizePathPrefix turns "v2", "/v2" and "/v2/" into "/v2"
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// debugEnabled reports whether the logger writes debug output, so request and
+// response bodies are only redacted and formatted when they will be logged
+func (c *BaseClient) debugEnabled() bool {
+	if l, ok := c.rawLogger.(interface{ Enabled(LogLevel) bool }); ok {
+		return l.Enabled(LevelDebug)
+	}
+	return true
+}
+
+// withHTTPTimeout bounds a single attempt by the WithTimeout duration or else,
+// unless the caller supplied their own deadline, by the timeout configured for
+// the endpoint
+func (c *BaseClient) withHTTPTimeout(ctx context.Context, path string, callOpts *callOptions, callerDeadline bool) (context.Context, context.CancelFunc) {
+	if callOpts.timeout > 0 {
+		return context.WithTimeout(ctx, callOpts.timeout)
+	}
+	if callerDeadline {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.endpointTimeout(path))
+}
+
+// endpointTimeout returns the EndpointTimeouts entry with the longest prefix
+// matching path, or the HTTP timeout when there is none
+func (c *BaseClient) endpointTimeout(path string) time.Duration {
+	timeout := c.config.HTTPTimeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	longest := -1
+	for prefix, d := range c.config.EndpointTimeouts {
+		if d > 0 && len(prefix) > longest && strings.HasPrefix(path, prefix) {
+			timeout, longest = d, len(prefix)
+		}
+	}
+	return timeout
+}
+
+func (c *BaseClient) getBasicAuth() string {
+	return basicCredentials(c.config.ClientID, c.config.ClientSecret)
+}
+
+func basicCredentials(clientID, clientSecret string) string {
+	return base64.StdEncoding.EncodeToString([]byte(clientID + ":" + clientSecret))
+}
+
+func (c *BaseClient) sendRequest(ctx context.Context, method, path string, queryParams map[string]string, body interface{}, opts ...CallOption) (*Response, error) {
+	return c.do(ctx, Request{
+		Method:      method,
+		Path:        path,
+		QueryParams: queryParams,
+		Body:        body,
+	}, opts...)
+}
+
+// Do sends a request to an arbitrary API path and returns the raw response,
+// leaving decoding to the caller. It is an escape hatch for endpoints that have
+// no typed method yet. Retries, rate limiting and logging apply as for typed calls.
+// On an error status the Response is returned together with an *APIError.
+func (c *BaseClient) Do(ctx context.Context, req Request, opts ...CallOption) (*Response, error) {
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+	return c.do(ctx, req, opts...)
+}
+
+func (c *BaseClient) do(ctx context.Context, request Request, opts ...CallOption) (*Response, error) {
+	method, path, queryParams := request.Method, request.Path, request.QueryParams
+	if err := c.beginRequest(); err != nil {
+		return nil, err
+	}
+	defer c.inFlight.Done()
+
+	callOpts := newCallOptions(opts)
+
+	// Bound the whole call, retries included, when the caller gave no deadline
+	_, callerDeadline := ctx.Deadline()
+	if !callerDeadline && c.config.DefaultRequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.DefaultRequestTimeout)
+		defer cancel()
+	}
+
+	params := url.Values{}
+	for key, value := range queryParams {
+		params.Add(key, value)
+	}
+	if len(callOpts.expand) > 0 {
+		params.Set("expand", strings.Join(callOpts.expand, ","))
+	}
+	fullURL := c.baseURL + path
+	if len(params) > 0 {
+		fullURL = fullURL + "?" + params.Encode()
+	}
+
+	var key string
+	if c.cache != nil && method == http.MethodGet {
+		key = cacheKey(method, fullURL, request.Headers, callOpts.headers)
+		if resp, ok := c.cache.get(key, c.clock.Now()); ok {
+			c.log(ctx).Debug("Serving API response from cache",
+				"method", method,
+				"path", path)
+			if callOpts.responseHeaders != nil {
+				*callOpts.responseHeaders = resp.Headers.Clone()
+			}
+			return resp, nil
+		}
+	}
+
+	var etagKey string
+	unconditional := request
+	if c.etags != nil && method == http.MethodGet {
+		etagKey = cacheKey(method, fullURL, request.Headers, callOpts.headers)
+		if stored := c.etags.get(etagKey); stored != nil {
+			request.Headers = withHeader(request.Headers, "If-None-Match", stored.Headers.Get("ETag"))
+		}
+	}
+
+	send := func() (*Response, error) {
+		return c.send(ctx, request, callOpts, fullURL, callerDeadline)
+	}
+	var resp *Response
+	var err error
+	if c.flight != nil && method == http.MethodGet {
+		resp, err = c.sendShared(cacheKey(method, fullURL, request.Headers, callOpts.headers), send)
+	} else {
+		resp, err = send()
+	}
+
+	if err == nil && etagKey != "" {
+		notModified := resp.StatusCode == http.StatusNotModified
+		var ok bool
+		resp, ok = c.etags.update(etagKey, resp)
+		switch {
+		case notModified && ok:
+			c.log(ctx).Debug("Resource not modified, using stored response",
+				"method", method,
+				"path", path)
+		case !ok:
+			// The stored response was dropped, e.g. by ClearCache, while the
+			// request was in flight, so there is no body to answer the 304 with
+			c.log(ctx).Debug("Stored response gone, repeating request without If-None-Match",
+				"method", method,
+				"path", path)
+			resp, err = c.send(ctx, unconditional, callOpts, fullURL, callerDeadline)
+			if err == nil {
+				resp, _ = c.etags.update(etagKey, resp)
+			}
+		}
+	}
+	if resp != nil && callOpts.responseHeaders != nil {
+		*callOpts.responseHeaders = resp.Headers.Clone()
+	}
+	if err == nil && key != "" {
+		c.cache.put(key, resp, c.clock.Now())
+	}
+	return resp, err
+}
+
+// send marshals the request body and performs the request, retrying transient
+// failures, against the already-built fullURL
+func (c *BaseClient) send(ctx context.Context, request Request, callOpts *callOptions, fullURL string, callerDeadline bool) (*Response, error) {
+	method, path, body := request.Method, request.Path, request.Body
+
+	c.log(ctx).Info("Sending API request",
+		"method", method,
+		"url", fullURL,
+		"path", path)
+
+	var jsonData []byte
+	if raw, ok := body.([]byte); ok {
+		jsonData = raw
+	} else if body != nil {
+		var err error
+		jsonData, err = json.Marshal(body)
+		if err != nil {
+			c.log(ctx).Error("Failed to marshal request body", err,
+				"method", method, "path", path)
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	if jsonData != nil && c.debugEnabled() {
+		c.log(ctx).Debug("Request body",
+			"method", method,
+			"path", path,
+			"request_body", c.logBody(jsonData))
+	}
+
+	if c.config.ReadOnly && method != http.MethodGet && method != http.MethodHead {
+		fields := []interface{}{"method", method, "url", fullURL}
+		if jsonData != nil {
+			fields = append(fields, "request_body", c.logBody(jsonData))
+		}
+		c.log(ctx).Warn("Read-only mode: request not sent", fields...)
+		return nil, fmt.Errorf("%s %s: %w", method, path, ErrReadOnlyMode)
+	}
+
+	// The same key is sent on every attempt so retries are deduplicated by the API
+	idempotencyKey := callOpts.idempotencyKey
+	if idempotencyKey == "" && !isSafeMethod(method) {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			c.log(ctx).Error("Failed to generate idempotency key", err,
+				"method", method, "path", path)
+			return nil, fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+		idempotencyKey = key
+	}
+
+	maxRetries := c.maxRetries(method, callOpts)
+	authRefreshed := false
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
+
+		if err := c.breaker.allow(c.clock.Now()); err != nil {
+			c.log(ctx).Warn("Circuit breaker is open, request not sent",
+				"method", method, "path", path)
+			return nil, err
+		}
+
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				c.log(ctx).Error("Rate limiter wait failed", err,
+					"method", method, "path", path)
+				return nil, fmt.Errorf("rate limiter: %w", err)
+			}
+		}
+
+		attemptCtx, cancel := c.withHTTPTimeout(ctx, path, callOpts, callerDeadline)
+
+		req, err := http.NewRequestWithContext(attemptCtx, method, fullURL, reqBody)
+		if err != nil {
+			cancel()
+			c.log(ctx).Error("Failed to create HTTP request", err,
+				"method", method, "url", fullURL)
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		authHeader, err := c.auth.authorization(attemptCtx)
+		if err != nil {
+			cancel()
+			c.log(ctx).Error("Failed to authenticate", err,
+				"method", method, "path", path)
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+		req.Header.Set("Authorization", authHeader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		// Setting Accept-Encoding ourselves turns off the transport's transparent
+		// decompression, so gzip bodies are decoded explicitly in doRequest. This
+		// keeps compression working with custom HTTP clients and transports too.
+		req.Header.Set("Accept-Encoding", "gzip")
+		if c.userAgent != "" {
+			req.Header.Set("User-Agent", c.userAgent)
+		}
+		if c.config.Locale != "" {
+			req.Header.Set("Accept-Language", c.config.Locale)
+		}
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		setCustomHeaders(req, request.Headers)
+		setCustomHeaders(req, callOpts.headers)
+
+		if err := c.runRequestInterceptors(req); err != nil {
+			cancel()
+			c.log(ctx).Error("Request interceptor aborted request", err,
+				"method", method, "path", path)
+			return nil, err
+		}
+
+		resp, err := c.doRequest(req, path)
+		cancel()
+		if errors.Is(err, errInterceptorAborted) {
+			return nil, err
+		}
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		// Attempts cancelled by the caller say nothing about the API's health
+		if ctx.Err() == nil && c.breaker.record(isOutage(statusCode, err), c.clock.Now()) {
+			c.log(ctx).Warn("Circuit breaker opened after repeated failures",
+				"method", method, "path", path)
+		}
+		// A rejected token is refreshed and the request repeated once. This does
+		// not count as a retry: the API did not process the request.
+		if statusCode == http.StatusUnauthorized && !authRefreshed && c.auth.invalidate(authHeader) {
+			authRefreshed = true
+			attempt--
+			c.log(ctx).Warn("Credentials rejected, retrying with a fresh token",
+				"method", method, "path", path)
+			continue
+		}
+
+		var retryAfter time.Duration
+		if statusCode == http.StatusTooManyRequests {
+			retryAfter = parseRetryAfter(resp.Headers.Get("Retry-After"), c.clock.Now())
+		}
+
+		// A Retry-After beyond maxRetryDelay is not waited out here: the
+		// RateLimitError carrying it is returned for the caller to act on.
+		if attempt < maxRetries && ctx.Err() == nil && shouldRetry(statusCode, err) && retryAfter <= maxRetryDelay {
+			delay := c.retryDelay(attempt)
+			if retryAfter > 0 {
+				delay = retryAfter
+			}
+			c.log(ctx).Warn("Retrying API request",
+				"method", method,
+				"path", path,
+				"attempt", attempt+1,
+				"status_code", statusCode,
+				"delay", delay.String())
+			if err := c.clock.Sleep(ctx, delay); err != nil {
+				return nil, fmt.Errorf("request cancelled while waiting to retry: %w", err)
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode >= 400 {
+			c.log(ctx).Error("API returned error response", nil,
+				"method", method,
+				"url", fullURL,
+				"status_code", resp.StatusCode,
+				"response", c.logBody(resp.Body))
+			apiErr := parseAPIError(resp.StatusCode, resp.Body)
+			apiErr.RequestID = requestID(resp.Headers)
+			if resp.StatusCode == http.StatusTooManyRequests {
+				return resp, &RateLimitError{RetryAfter: retryAfter, Err: apiErr}
+			}
+			return resp, apiErr
+		}
+
+		return resp, nil
+	}
+}
+
+// readBody reads the response body, decompressing it when it is gzip-encoded.
+// Bodies longer than limit bytes, after decompression, fail with
+// ErrResponseTooLarge without being buffered in full; a negative limit disables
+// the check. Responses that carry no body (HEAD, 204, 304 or an empty payload)
+// are returned as-is even when a Content-Encoding header is present.
+func readBody(resp *http.Response, limit int64) ([]byte, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") || !hasBody(resp) {
+		return readLimited(resp.Body, limit)
+	}
+
+	buffered := bufio.NewReader(resp.Body)
+	if _, err := buffered.Peek(1); err == io.EOF {
+		return []byte{}, nil
+	}
+
+	reader, err := gzip.NewReader(buffered)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip body: %w", err)
+	}
+	defer reader.Close()
+
+	body, err := readLimited(reader, limit)
+	if errors.Is(err, ErrResponseTooLarge) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip body: %w", err)
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	return body, nil
+}
+
+// hasBody reports whether a response may carry a body per RFC 9110
+func hasBody(resp *http.Response) bool {
+	if resp.Request != nil && resp.Request.Method == http.MethodHead {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotModified:
+		return false
+	}
+	return resp.ContentLength != 0
+}
+
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit < 0 {
+		return io.ReadAll(r)
+	}
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+	return body, nil
+}
+
+// maxResponseBytes returns the response size limit, negative when disabled
+func (c *BaseClient) maxResponseBytes() int64 {
+	if c.config.MaxResponseBytes == 0 {
+		return defaultMaxResponseBytes
+	}
+	return c.config.MaxResponseBytes
+}
+
+// isBinaryContent reports whether a response carries a document such as an
+// invoice PDF rather than JSON, so its body is kept out of debug logs
+func isBinaryContent(headers http.Header) bool {
+	contentType := strings.ToLower(headers.Get("Content-Type"))
+	return strings.HasPrefix(contentType, "application/pdf") ||
+		strings.HasPrefix(contentType, "application/octet-stream")
+}
+
+// requestIDHeaders are the response headers that may carry the Cleverbridge request id
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id"}
+
+func requestID(headers http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := headers.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// setCustomHeaders adds caller-supplied headers to a request. The Authorization
+// header is managed by the client and is never overwritten this way.
+func setCustomHeaders(req *http.Request, headers map[string]string) {
+	for key, value := range headers {
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+}
+
+// doRequest performs a single HTTP round trip and reads the response body
+func (c *BaseClient) doRequest(req *http.Request, path string) (*Response, error) {
+	ctx := req.Context()
+	method := req.Method
+	fullURL := req.URL.String()
+
+	startTime := time.Now()
+	resp, err := c.httpClient.Do(req)
+	requestDuration := time.Since(startTime)
+
+	if err != nil {
+		c.recordMetrics(method, path, 0, requestDuration, true)
+		c.logAccess(method, path, 0, startTime, 0)
+		c.log(ctx).Error("HTTP request failed", err,
+			"method", method,
+			"url", fullURL,
+			"duration", requestDuration.String())
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	c.recordMetrics(method, path, resp.StatusCode, requestDuration, false)
+
+	if err := c.runResponseInterceptors(resp); err != nil {
+		c.log(ctx).Error("Response interceptor aborted request", err,
+			"method", method,
+			"path", path,
+			"status_code", resp.StatusCode)
+		return nil, err
+	}
+
+	responseBody, err := readBody(resp, c.maxResponseBytes())
+	if err != nil {
+		c.log(ctx).Error("Failed to read response body", err,
+			"method", method,
+			"url", fullURL,
+			"status_code", resp.StatusCode)
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	c.logAccess(method, path, resp.StatusCode, startTime, len(responseBody))
+
+	fields := []interface{}{
+		"method", method,
+		"path", path,
+		"request_id", requestID(resp.Header),
+		"status_code", resp.StatusCode,
+		"duration", requestDuration.String(),
+		"response_size", len(responseBody),
+	}
+	if status, ok := parseRateLimit(resp.Header, c.clock.Now()); ok {
+		c.rateLimit.update(status)
+		fields = append(fields,
+			"rate_limit_limit", status.Limit,
+			"rate_limit_remaining", status.Remaining,
+			"rate_limit_reset", status.Reset)
+	}
+	c.log(ctx).Info("API response received", fields...)
+
+	if c.debugEnabled() && len(responseBody) > 0 && !isBinaryContent(resp.Header) {
+		c.log(ctx).Debug("Response body",
+			"method", method,
+			"path", path,
+			"status_code", resp.StatusCode,
+			"response_body", c.logBody(responseBody))
+	}
+
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Body:       responseBody,
+		Headers:    resp.Header,
+		path:       path,
+	}, nil
+}
+
+// Ping checks connectivity and credentials with an authenticated HEAD request
+// against the base URL. It returns nil on a 2xx response and an error matching
+// ErrUnauthorized when the credentials are rejected.
+func (c *BaseClient) Ping(ctx context.Context) error {
+	c.log(ctx).Info("Pinging Cleverbridge API", "base_url", c.baseURL)
+
+	if _, err := c.do(ctx, Request{Method: http.MethodHead}); err != nil {
+		c.log(ctx).Error("Ping failed", err, "base_url", c.baseURL)
+		return fmt.Errorf("ping failed: %w", err)
+	}
+
+	c.log(ctx).Info("Ping succeeded", "base_url", c.baseURL)
+	return nil
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewBaseClientBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+		wantErr string
+	}{
+		{"trailing slash", "https://rest.cleverbridge.com/", "https://rest.cleverbridge.com", ""},
+		{"several trailing slashes", "https://rest.cleverbridge.com//", "https://rest.cleverbridge.com", ""},
+		{"surrounding spaces", " https://rest.cleverbridge.com ", "https://rest.cleverbridge.com", ""},
+		{"missing scheme", "rest.cleverbridge.com", "", "scheme must be http or https"},
+		{"unsupported scheme", "ftp://rest.cleverbridge.com", "", "scheme must be http or https"},
+		{"missing host", "https://", "", "missing host"},
+		{"empty", "", "", "base_url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &CleverbridgeConfig{ClientID: "id", ClientSecret: "secret", BaseURL: tt.baseURL}
+			c, err := NewBaseClient(config, WithLogger(nopLogger{}))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewBaseClient(%q) error = %v, want it to mention %q", tt.baseURL, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewBaseClient(%q) error: %v", tt.baseURL, err)
+			}
+			if c.baseURL != tt.want {
+				t.Errorf("baseURL = %q, want %q", c.baseURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBaseClientValidatesAfterOptions(t *testing.T) {
+	config := &CleverbridgeConfig{ClientID: "id", ClientSecret: "secret"}
+
+	c, err := NewBaseClient(config, WithLogger(nopLogger{}), WithBaseURL("https://rest.cleverbridge.com/"))
+	if err != nil {
+		t.Fatalf("NewBaseClient with WithBaseURL error: %v", err)
+	}
+	if c.baseURL != "https://rest.cleverbridge.com" {
+		t.Errorf("baseURL = %q, want https://rest.cleverbridge.com", c.baseURL)
+	}
+	if config.BaseURL != "" {
+		t.Errorf("config.BaseURL = %q, want the caller's config left unchanged", config.BaseURL)
+	}
+
+	config.BaseURL = "https://rest.cleverbridge.com"
+	_, err = NewBaseClient(config, WithLogger(nopLogger{}), WithBaseURL("ftp://rest.cleverbridge.com"))
+	if err == nil || !strings.Contains(err.Error(), "scheme must be http or https") {
+		t.Errorf("NewBaseClient with an invalid WithBaseURL error = %v, want the scheme rejected", err)
+	}
+}
+
+func TestTrailingSlashDoesNotDoubleSlashPaths(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"id":"C1"}`))
+	}))
+	defer server.Close()
+
+	config := &CleverbridgeConfig{ClientID: "id", ClientSecret: "secret", BaseURL: server.URL + "/"}
+	c, err := NewBaseClient(config, WithLogger(nopLogger{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCustomer(context.Background(), "C1"); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/customer/getcustomer" {
+		t.Errorf("request path = %q, want /customer/getcustomer", gotPath)
+	}
+}
+
+func TestGzipResponseRoundTrip(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", got)
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(`{"id":"S1","status":"active"}`))
+		zw.Close()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	}))
+
+	subscription, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+	if err != nil {
+		t.Fatalf("GetSubscription error: %v", err)
+	}
+	if subscription.ID != "S1" || subscription.Status != StatusActive {
+		t.Errorf("subscription = %+v, want S1 active", subscription)
+	}
+}
+
+func TestGzipHeaderWithoutBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		call   func(c *BaseClient) error
+	}{
+		{"HEAD ping", http.StatusOK, func(c *BaseClient) error {
+			return c.Ping(context.Background())
+		}},
+		{"204 No Content", http.StatusNoContent, func(c *BaseClient) error {
+			_, err := c.CancelSubscription(context.Background(), "S1", "")
+			return err
+		}},
+		{"empty 200", http.StatusOK, func(c *BaseClient) error {
+			_, err := c.CancelSubscription(context.Background(), "S1", "")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "gzip")
+				w.WriteHeader(tt.status)
+			}))
+			if err := tt.call(c); err != nil {
+				t.Errorf("error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestEndpointTimeouts(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+			w.Write([]byte(`{}`))
+		case <-r.Context().Done():
+		}
+	})
+	c := newTestClient(t, slow, func(c *BaseClient) {
+		c.config.HTTPTimeout = 50 * time.Millisecond
+		c.config.EndpointTimeouts = map[string]time.Duration{"/export": 2 * time.Second}
+	})
+	ctx := context.Background()
+
+	if _, err := c.sendRequest(ctx, http.MethodGet, "/export/subscriptions", nil, nil); err != nil {
+		t.Errorf("slow endpoint with a longer timeout failed: %v", err)
+	}
+	if _, err := c.sendRequest(ctx, http.MethodGet, "/subscription/getsubscription", nil, nil); err == nil {
+		t.Error("slow request under the default timeout succeeded, want a timeout")
+	}
+	if _, err := c.sendRequest(ctx, http.MethodGet, "/subscription/getsubscription", nil, nil, WithTimeout(2*time.Second)); err != nil {
+		t.Errorf("slow request with WithTimeout failed: %v", err)
+	}
+}
+
+// endlessReader yields zeros forever, counting how many bytes were read
+type endlessReader struct {
+	read int64
+}
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = '0'
+	}
+	r.read += int64(len(p))
+	return len(p), nil
+}
+
+func (r *endlessReader) Close() error { return nil }
+
+func TestReadBodyStopsAtLimit(t *testing.T) {
+	body := &endlessReader{}
+	resp := &http.Response{StatusCode: http.StatusOK, ContentLength: -1, Header: http.Header{}, Body: body}
+
+	_, err := readBody(resp, 1024)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("error = %v, want ErrResponseTooLarge", err)
+	}
+	if body.read > 64<<10 {
+		t.Errorf("read %d bytes of an endless body, want reading to stop near the 1024 byte limit", body.read)
+	}
+}
+
+func TestOversizedResponseIsRejected(t *testing.T) {
+	var attempts int32
+	large := strings.Repeat("x", 4096)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Write([]byte(`{"id":"S1","plan":"` + large + `"}`))
+	}), WithMaxResponseSize(1024), withMaxRetries(3))
+
+	_, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("error = %v, want ErrResponseTooLarge", err)
+	}
+	if attempts != 1 {
+		t.Errorf("sent %d times, want 1 as the same body would be downloaded again", attempts)
+	}
+}
@@ -0,0 +1,66 @@
+// Package clienttest provides helpers for testing code that uses the Cleverbridge
+// client without reaching the live API.
+package clienttest
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"cb_api_client/internal/client"
+)
+
+// BaseURL is the base URL of clients returned by NewTestClient
+const BaseURL = "http://cleverbridge.test"
+
+// NewTestClient returns a client whose requests are served in-process by handler,
+// e.g. an http.ServeMux returning canned JSON. Retries are disabled and nothing is logged.
+func NewTestClient(handler http.Handler, opts ...client.Option) *client.BaseClient {
+	config := &client.CleverbridgeConfig{
+		ClientID:     "test-client-id",
+		ClientSecret: "test-client-secret",
+		BaseURL:      BaseURL,
+		MaxRetries:   -1,
+	}
+
+	opts = append([]client.Option{
+		client.WithHTTPClient(&http.Client{Transport: HandlerTransport{Handler: handler}}),
+		client.WithLogger(discardLogger{}),
+	}, opts...)
+
+	c, err := client.NewBaseClient(config, opts...)
+	if err != nil {
+		panic("clienttest: " + err.Error())
+	}
+	return c
+}
+
+// HandlerTransport is an http.RoundTripper that serves requests with an http.Handler
+// instead of sending them over the network
+type HandlerTransport struct {
+	Handler http.Handler
+}
+
+func (t HandlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	t.Handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
+
+// JSONHandler returns a handler that always answers with the given status and JSON body
+func JSONHandler(statusCode int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Debug(string, ...interface{})        {}
+func (discardLogger) Info(string, ...interface{})         {}
+func (discardLogger) Warn(string, ...interface{})         {}
+func (discardLogger) Error(string, error, ...interface{}) {}
@@ -0,0 +1,33 @@
+package clienttest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"cb_api_client/internal/client"
+	"cb_api_client/internal/client/clienttest"
+)
+
+func TestNewTestClientServesCannedJSON(t *testing.T) {
+	c := clienttest.NewTestClient(clienttest.JSONHandler(http.StatusOK, `{"id":"S1","status":"active"}`))
+	defer c.Close()
+
+	subscription, err := c.GetSubscription(context.Background(), "S1", client.SubscriptionViewCurrent)
+	if err != nil {
+		t.Fatalf("GetSubscription error: %v", err)
+	}
+	if subscription.ID != "S1" {
+		t.Errorf("ID = %q, want S1", subscription.ID)
+	}
+}
+
+func TestNewTestClientErrorResponse(t *testing.T) {
+	c := clienttest.NewTestClient(clienttest.JSONHandler(http.StatusNotFound, `{"message":"not found"}`))
+	defer c.Close()
+
+	if _, err := c.GetSubscription(context.Background(), "S1", client.SubscriptionViewCurrent); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,259 @@
+package clienttest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Cassette is a recorded sequence of API interactions, stored as JSON
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and the response it received. Request
+// headers and bodies are not recorded, and Save redacts tokens and credentials
+// in responses, so secrets never end up on disk.
+type Interaction struct {
+	Method   string         `json:"method"`
+	Path     string         `json:"path"`
+	Query    string         `json:"query"`
+	Response RecordedResult `json:"response"`
+}
+
+// RecordedResult is the recorded response of an Interaction. Body is stored
+// byte for byte, base64-encoded in the cassette, so binary responses such as
+// PDF invoices replay unchanged.
+type RecordedResult struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers"`
+	Body       []byte      `json:"body"`
+}
+
+// matches reports whether the interaction was recorded for req. Query
+// parameters are compared regardless of their order.
+func (i Interaction) matches(req *http.Request) bool {
+	return i.Method == req.Method && i.Path == req.URL.Path && i.Query == req.URL.Query().Encode()
+}
+
+// LoadCassette reads a cassette written by Recorder.Save
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Recorder is an http.RoundTripper that sends requests through Next, e.g.
+// http.DefaultTransport, and records every response. Call Save once done to
+// write the cassette for a Replayer:
+//
+//	rec := &clienttest.Recorder{Next: http.DefaultTransport}
+//	c, _ := client.NewBaseClient(cfg, client.WithHTTPClient(&http.Client{Transport: rec}))
+//	// ... exercise the client against the live API ...
+//	err := rec.Save("testdata/subscriptions.json")
+type Recorder struct {
+	Next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	headers := resp.Header.Clone()
+	// Cassettes hold plain text bodies, so compressed responses are stored decoded
+	if strings.EqualFold(headers.Get("Content-Encoding"), "gzip") {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		if body, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		headers.Del("Content-Encoding")
+		headers.Del("Content-Length")
+	}
+
+	interaction := Interaction{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Response: RecordedResult{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       body,
+		},
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return interaction.Response.httpResponse(req), nil
+}
+
+// Save writes the recorded interactions to path as an indented JSON cassette.
+// Credential headers such as Authorization and Set-Cookie, and token fields
+// such as access_token in JSON bodies, are replaced with "***" in the file; the
+// client saw the real values while recording.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette := Cassette{Interactions: make([]Interaction, len(r.cassette.Interactions))}
+	for i, interaction := range r.cassette.Interactions {
+		interaction.Response = interaction.Response.redacted()
+		cassette.Interactions[i] = interaction
+	}
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// Replayer is an http.RoundTripper serving the responses of a cassette without
+// network access, matching requests on method, path and query. Interactions
+// recorded for the same request are served in recording order; the last one
+// is repeated once they are used up. Requests without a recording fail.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer loads the cassette at path for replay
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !interaction.matches(req) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction.Response.httpResponse(req), nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return r.cassette.Interactions[last].Response.httpResponse(req), nil
+	}
+	return nil, fmt.Errorf("clienttest: no recorded interaction for %s %s", req.Method, req.URL.RequestURI())
+}
+
+func (r RecordedResult) httpResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		StatusCode:    r.StatusCode,
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// redactedValue replaces secrets in saved cassettes
+const redactedValue = "***"
+
+// sensitiveHeaders are response headers that carry credentials
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Set-Cookie", "WWW-Authenticate"}
+
+// sensitiveFields are JSON body fields that carry credentials, as returned by
+// the OAuth2 token endpoint
+var sensitiveFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+	"password":      true,
+}
+
+// redacted returns a copy of the result with credentials masked
+func (r RecordedResult) redacted() RecordedResult {
+	r.Headers = r.Headers.Clone()
+	for _, name := range sensitiveHeaders {
+		if r.Headers.Get(name) != "" {
+			r.Headers.Set(name, redactedValue)
+		}
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(r.Body, &decoded); err != nil {
+		return r
+	}
+	if redactedBody, changed := redactFields(decoded); changed {
+		if body, err := json.Marshal(redactedBody); err == nil {
+			r.Body = body
+			r.Headers.Del("Content-Length")
+		}
+	}
+	return r
+}
+
+// redactFields masks sensitive fields of a decoded JSON value, reporting
+// whether anything was masked
+func redactFields(value interface{}) (interface{}, bool) {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+				changed = true
+				continue
+			}
+			var itemChanged bool
+			v[key], itemChanged = redactFields(item)
+			changed = changed || itemChanged
+		}
+	case []interface{}:
+		for i, item := range v {
+			var itemChanged bool
+			v[i], itemChanged = redactFields(item)
+			changed = changed || itemChanged
+		}
+	}
+	return value, changed
+}
@@ -0,0 +1,81 @@
+package clienttest_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cb_api_client/internal/client/clienttest"
+)
+
+const secretToken = "eyJhbGciOiJSUzI1NiJ9.live-token"
+
+var pdfBody = []byte{'%', 'P', 'D', 'F', '-', '1', '.', '7', '\n', 0x00, 0xff, 0xfe, 0x80, '\n'}
+
+func get(t *testing.T, httpClient *http.Client, url string) []byte {
+	t.Helper()
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestRecorderRedactsAndKeepsBinaryBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Header().Set("Authorization", "Bearer "+secretToken)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"` + secretToken + `","token_type":"Bearer","expires_in":3600}`))
+		case "/invoice/getinvoicepdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write(pdfBody)
+		}
+	}))
+	defer server.Close()
+
+	rec := &clienttest.Recorder{Next: http.DefaultTransport}
+	httpClient := &http.Client{Transport: rec}
+
+	if body := get(t, httpClient, server.URL+"/oauth/token"); !bytes.Contains(body, []byte(secretToken)) {
+		t.Errorf("recording returned %s, want the real token", body)
+	}
+	get(t, httpClient, server.URL+"/invoice/getinvoicepdf")
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(saved), "live-token") {
+		t.Errorf("cassette contains the token:\n%s", saved)
+	}
+
+	replayer, err := clienttest.NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient = &http.Client{Transport: replayer}
+
+	if body := get(t, httpClient, server.URL+"/invoice/getinvoicepdf"); !bytes.Equal(body, pdfBody) {
+		t.Errorf("replayed PDF = %q, want %q", body, pdfBody)
+	}
+	body := get(t, httpClient, server.URL+"/oauth/token")
+	if !strings.Contains(string(body), `"access_token":"***"`) || !strings.Contains(string(body), `"expires_in":3600`) {
+		t.Errorf("replayed token response = %s, want access_token redacted and other fields kept", body)
+	}
+}
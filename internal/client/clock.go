@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the time source of the client. Retry backoff, Retry-After handling,
+// cache expiry, the circuit breaker and OAuth2 token refresh all go through it,
+// so tests can inject a fake clock and advance time instead of waiting. Request
+// durations in logs and metrics are always measured with the real clock.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for d or until ctx is done, returning ctx.Err() in that case
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// WithClock replaces the real clock, e.g. with a fake one in tests
+func WithClock(clock Clock) Option {
+	return func(c *BaseClient) {
+		c.clock = clock
+	}
+}
+
+// realClock is the default Clock backed by the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
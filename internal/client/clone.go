@@ -0,0 +1,65 @@
+package client
+
+import "golang.org/x/sync/singleflight"
+
+// WithCredentials returns a client for another Cleverbridge account, e.g. one
+// per tenant, that uses the given credentials and otherwise the same settings.
+// The copy sends requests through the same HTTP client, so connections are
+// pooled across tenants, and writes to the same logger, interceptors, metrics
+// and access log. Everything tied to an account is its own: authentication,
+// the rate limiter, the circuit breaker, the response cache, conditional
+// request and single-flight state, and LastRateLimit. Both clients can be used
+// concurrently; Close and Shutdown affect only the client they are called on.
+// It returns the configuration error, rather than panicking, when the new
+// credentials are invalid, e.g. an empty clientID or clientSecret, so callers
+// must check it before using the copy.
+func (c *BaseClient) WithCredentials(clientID, clientSecret string) (*BaseClient, error) {
+	cfg := *c.config
+	cfg.ClientID = clientID
+	cfg.ClientSecret = clientSecret
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	clone := &BaseClient{
+		httpClient:           c.httpClient,
+		baseURL:              c.baseURL,
+		config:               &cfg,
+		rawLogger:            c.rawLogger,
+		userAgent:            c.userAgent,
+		limiter:              newLimiter(&cfg),
+		metrics:              c.metrics,
+		rootCAs:              c.rootCAs,
+		requestInterceptors:  append([]RequestInterceptor(nil), c.requestInterceptors...),
+		responseInterceptors: append([]ResponseInterceptor(nil), c.responseInterceptors...),
+		breaker:              newCircuitBreaker(&cfg),
+		converter:            c.converter,
+		accessLog:            c.accessLog,
+		clock:                c.clock,
+	}
+
+	// Cached responses belong to the account that fetched them
+	if c.cache != nil {
+		WithCache(c.cache.ttl)(clone)
+	}
+	if c.etags != nil {
+		WithConditionalRequests()(clone)
+	}
+	if c.flight != nil {
+		clone.flight = &singleflight.Group{}
+	}
+
+	auth, err := newAuthStrategy(clone.config, clone.httpClient, clone.clock)
+	if err != nil {
+		return nil, err
+	}
+	clone.auth = auth
+
+	// The clone holds its own reference to the logger, released by its Close
+	if r, ok := clone.rawLogger.(interface{ retain() }); ok {
+		r.retain()
+	}
+	clone.logger = newRedactingLogger(clone.rawLogger, clientSecret, clone.getBasicAuth())
+
+	return clone, nil
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithCredentials(t *testing.T) {
+	var authHeaders []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"id":"S1"}`))
+	}))
+
+	tenant, err := c.WithCredentials("tenant-id", "tenant-secret")
+	if err != nil {
+		t.Fatalf("WithCredentials error: %v", err)
+	}
+	defer tenant.Close()
+
+	ctx := context.Background()
+	if _, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tenant.GetSubscription(ctx, "S1", SubscriptionViewCurrent); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Basic " + basicCredentials("test-client-id", "test-client-secret"),
+		"Basic " + basicCredentials("tenant-id", "tenant-secret"),
+	}
+	if len(authHeaders) != 2 || authHeaders[0] != want[0] || authHeaders[1] != want[1] {
+		t.Errorf("Authorization headers = %q, want %q", authHeaders, want)
+	}
+	if c.config.ClientID != "test-client-id" {
+		t.Errorf("original client ID = %q, want it unchanged", c.config.ClientID)
+	}
+}
+
+func TestWithCredentialsRejectsEmptyCredentials(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{}`))
+
+	tests := []struct {
+		name, clientID, clientSecret, want string
+	}{
+		{"empty client ID", "", "secret", "client_id"},
+		{"empty client secret", "id", "", "client_secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clone, err := c.WithCredentials(tt.clientID, tt.clientSecret)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("WithCredentials error = %v, want it to mention %s", err, tt.want)
+			}
+			if clone != nil {
+				t.Error("WithCredentials returned a client along with the error")
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+)
+
+// etagStore keeps the last successful GET response carrying an ETag per request,
+// to revalidate it with If-None-Match
+type etagStore struct {
+	mu      sync.Mutex
+	entries map[string]*Response
+}
+
+// WithConditionalRequests remembers the ETag of successful GET responses and
+// sends it as If-None-Match when the same request is repeated. When the API
+// answers 304 Not Modified, the stored response is returned in its place, so
+// polling loops only transfer bodies that changed. Unlike WithCache every call
+// still reaches the API. Use ClearCache to drop stored responses.
+func WithConditionalRequests() Option {
+	return func(c *BaseClient) {
+		c.etags = &etagStore{entries: make(map[string]*Response)}
+	}
+}
+
+func (s *etagStore) get(key string) *Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[key]
+}
+
+// update stores a successful response carrying an ETag and swaps a 304 Not
+// Modified response for the stored one. It reports false for a 304 when there
+// is no stored response to swap in.
+func (s *etagStore) update(key string, resp *Response) (*Response, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if stored, ok := s.entries[key]; ok {
+			return stored.clone(), true
+		}
+		return resp, false
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 && resp.Headers.Get("ETag") != "" && !noStore(resp.Headers) {
+		s.entries[key] = resp.clone()
+	}
+	return resp, true
+}
+
+func (s *etagStore) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]*Response)
+}
+
+// withHeader returns a copy of headers with key set to value
+func withHeader(headers map[string]string, key, value string) map[string]string {
+	merged := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestConditionalRequestUsesStoredResponseOn304(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":"S1","status":"active"}`))
+	}), WithConditionalRequests())
+
+	for i := 0; i < 2; i++ {
+		subscription, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if subscription.ID != "S1" || subscription.Status != StatusActive {
+			t.Errorf("call %d: subscription = %+v, want S1 active", i, subscription)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("server hit %d times, want 2", requests)
+	}
+}
+
+func TestConditionalRequestRefetchesWhen304HasNoStoredResponse(t *testing.T) {
+	var c *BaseClient
+	var requests int
+	c = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") != "" {
+			// The stored response disappears while the revalidation is in flight
+			c.ClearCache()
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":"S1","status":"active"}`))
+	}), WithConditionalRequests())
+
+	ctx := context.Background()
+	if _, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent); err != nil {
+		t.Fatal(err)
+	}
+	subscription, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+	if err != nil {
+		t.Fatalf("GetSubscription after 304 error: %v", err)
+	}
+	if subscription.ID != "S1" || subscription.Status != StatusActive {
+		t.Errorf("subscription = %+v, want S1 active instead of an empty 304 body", subscription)
+	}
+	if requests != 3 {
+		t.Errorf("server hit %d times, want 3", requests)
+	}
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfig reads the cleverbridge section of a YAML config file and overlays
+// the CB_CLIENT_ID, CB_CLIENT_SECRET, CB_BASE_URL and CB_DEBUG environment
+// variables, which take precedence over the file. The result is checked with Validate.
+func LoadConfig(path string) (*CleverbridgeConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var fileConfig struct {
+		Cleverbridge CleverbridgeConfig `yaml:"cleverbridge"`
+	}
+	if err := yaml.Unmarshal(data, &fileConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	config := &fileConfig.Cleverbridge
+	if err := config.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// applyEnv overrides config values with the CB_* environment variables that are set
+func (c *CleverbridgeConfig) applyEnv() error {
+	if value, ok := os.LookupEnv("CB_CLIENT_ID"); ok {
+		c.ClientID = value
+	}
+	if value, ok := os.LookupEnv("CB_CLIENT_SECRET"); ok {
+		c.ClientSecret = value
+	}
+	if value, ok := os.LookupEnv("CB_BASE_URL"); ok {
+		c.BaseURL = value
+	}
+	if value, ok := os.LookupEnv("CB_DEBUG"); ok {
+		debug, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid CB_DEBUG value %q: %w", value, err)
+		}
+		c.Debug = debug
+	}
+	return nil
+}
+
+// Validate checks the config without building a client or opening a log file:
+// required fields, URLs, enumerated values and numeric ranges. All problems are
+// reported at once, joined with errors.Join. NewBaseClient calls it too.
+func (c *CleverbridgeConfig) Validate() error {
+	var errs []error
+
+	var missing []string
+	if c.ClientID == "" {
+		missing = append(missing, "client_id")
+	}
+	if c.ClientSecret == "" {
+		missing = append(missing, "client_secret")
+	}
+	if c.BaseURL == "" {
+		missing = append(missing, "base_url")
+	}
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Errorf("missing required config fields: %s", strings.Join(missing, ", ")))
+	}
+
+	if c.BaseURL != "" {
+		if _, err := normalizeBaseURL(c.BaseURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.ProxyURL != "" {
+		if _, err := url.Parse(c.ProxyURL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid proxy_url %q: %w", c.ProxyURL, err))
+		}
+	}
+
+	switch strings.ToLower(c.AuthMode) {
+	case "", AuthModeBasic:
+	case AuthModeOAuth2:
+		if c.TokenURL == "" {
+			errs = append(errs, fmt.Errorf("token_url is required for auth_mode %q", AuthModeOAuth2))
+		} else if _, err := normalizeBaseURL(c.TokenURL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid token_url: %w", err))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid auth_mode %q, expected %q or %q", c.AuthMode, AuthModeBasic, AuthModeOAuth2))
+	}
+
+	if c.LogLevel != "" {
+		if _, err := ParseLogLevel(c.LogLevel); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	switch strings.ToLower(c.LogFormat) {
+	case "", "text", "json":
+	default:
+		errs = append(errs, fmt.Errorf("invalid log_format %q, expected text or json", c.LogFormat))
+	}
+	switch c.MinTLSVersion {
+	case "", "1.2", "1.3":
+	default:
+		errs = append(errs, fmt.Errorf("unsupported min_tls_version %q: use 1.2 or 1.3", c.MinTLSVersion))
+	}
+
+	nonNegative := []struct {
+		name  string
+		value float64
+	}{
+		{"http_timeout", float64(c.HTTPTimeout)},
+		{"default_request_timeout", float64(c.DefaultRequestTimeout)},
+		{"retry_base_delay", float64(c.RetryBaseDelay)},
+		{"idle_conn_timeout", float64(c.IdleConnTimeout)},
+		{"circuit_breaker_window", float64(c.CircuitBreakerWindow)},
+		{"circuit_breaker_cooldown", float64(c.CircuitBreakerCooldown)},
+		{"circuit_breaker_threshold", float64(c.CircuitBreakerThreshold)},
+		{"max_idle_conns", float64(c.MaxIdleConns)},
+		{"max_idle_conns_per_host", float64(c.MaxIdleConnsPerHost)},
+		{"requests_per_second", c.RequestsPerSecond},
+		{"burst", float64(c.Burst)},
+		{"max_concurrency", float64(c.MaxConcurrency)},
+		{"log_max_size_mb", float64(c.LogMaxSizeMB)},
+		{"log_max_backups", float64(c.LogMaxBackups)},
+	}
+	for _, field := range nonNegative {
+		if field.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", field.name))
+		}
+	}
+	if c.MaxRetries > maxMaxRetries {
+		errs = append(errs, fmt.Errorf("max_retries must be at most %d", maxMaxRetries))
+	}
+	for prefix, timeout := range c.EndpointTimeouts {
+		if timeout < 0 {
+			errs = append(errs, fmt.Errorf("endpoint_timeouts[%q] must not be negative", prefix))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,55 @@
+package client
+
+import "context"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID attaches a caller-chosen request or correlation id to ctx.
+// Every log line the client writes for calls made with ctx carries it as the
+// correlation_id field, so interleaved logs of concurrent operations can be told
+// apart.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the id attached with ContextWithRequestID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// log returns the client logger, adding the correlation id from ctx if there is one
+func (c *BaseClient) log(ctx context.Context) Logger {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return &fieldLogger{logger: c.logger, fields: []interface{}{"correlation_id", id}}
+	}
+	return c.logger
+}
+
+// fieldLogger adds fixed fields to every log line
+type fieldLogger struct {
+	logger Logger
+	fields []interface{}
+}
+
+func (l *fieldLogger) with(fields []interface{}) []interface{} {
+	all := make([]interface{}, 0, len(l.fields)+len(fields))
+	all = append(all, l.fields...)
+	return append(all, fields...)
+}
+
+func (l *fieldLogger) Debug(message string, fields ...interface{}) {
+	l.logger.Debug(message, l.with(fields)...)
+}
+
+func (l *fieldLogger) Info(message string, fields ...interface{}) {
+	l.logger.Info(message, l.with(fields)...)
+}
+
+func (l *fieldLogger) Warn(message string, fields ...interface{}) {
+	l.logger.Warn(message, l.with(fields)...)
+}
+
+func (l *fieldLogger) Error(message string, err error, fields ...interface{}) {
+	l.logger.Error(message, err, l.with(fields)...)
+}
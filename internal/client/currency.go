@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CurrencyConverter converts amounts between ISO 4217 currencies, e.g. using the
+// caller's FX rates. The client never fetches rates itself.
+type CurrencyConverter interface {
+	Convert(amount Money, from, to string) (Money, error)
+}
+
+// noopConverter is the default converter. It returns amounts that are already
+// in the target currency unchanged and fails for any other pair.
+type noopConverter struct{}
+
+func (noopConverter) Convert(amount Money, from, to string) (Money, error) {
+	if strings.EqualFold(from, to) {
+		return amount, nil
+	}
+	return 0, fmt.Errorf("cannot convert %s %s to %s: no currency converter configured, see WithCurrencyConverter", amount, from, to)
+}
+
+// WithCurrencyConverter sets the converter used by NormalizeAmount
+func WithCurrencyConverter(converter CurrencyConverter) Option {
+	return func(c *BaseClient) {
+		c.converter = converter
+	}
+}
+
+// NormalizeAmount converts amount from one currency to another with the
+// configured CurrencyConverter, e.g. to report all subscriptions in EUR.
+// Amounts already in the target currency are returned unchanged.
+func (c *BaseClient) NormalizeAmount(amount Money, from, to string) (Money, error) {
+	if strings.EqualFold(from, to) {
+		return amount, nil
+	}
+
+	converter := c.converter
+	if converter == nil {
+		converter = noopConverter{}
+	}
+	return converter.Convert(amount, strings.ToUpper(from), strings.ToUpper(to))
+}
@@ -0,0 +1,14 @@
+package client
+
+import "testing"
+
+func TestNoopConverter(t *testing.T) {
+	got, err := noopConverter{}.Convert(Money(1999), "EUR", "eur")
+	if err != nil || got != Money(1999) {
+		t.Errorf("Convert(EUR to eur) = %v, %v, want 19.99, nil", got, err)
+	}
+
+	if _, err := (noopConverter{}).Convert(Money(1999), "EUR", "USD"); err == nil {
+		t.Error("Convert(EUR to USD) succeeded, want an error")
+	}
+}
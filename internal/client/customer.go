@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *BaseClient) GetCustomer(ctx context.Context, customerID string, opts ...CallOption) (*Customer, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customer ID is required")
+	}
+
+	c.log(ctx).Info("Getting customer", "customer_id", customerID)
+
+	queryParams := query{}.set("customerId", customerID)
+
+	resp, err := c.sendRequest(ctx, "GET", "/customer/getcustomer", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get customer", err,
+			"customer_id", customerID)
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	var customer Customer
+	if err := c.decodeJSON(resp, &customer); err != nil {
+		c.log(ctx).Error("Failed to parse customer response", err,
+			"customer_id", customerID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse customer: %w", err)
+	}
+
+	// Cleverbridge answers unknown customers with an empty object instead of a 404
+	if customer == (Customer{}) {
+		c.log(ctx).Warn("Customer not found", "customer_id", customerID)
+		return nil, fmt.Errorf("customer %s: %w", customerID, ErrNotFound)
+	}
+
+	c.log(ctx).Info("Successfully retrieved customer",
+		"customer_id", customer.ID,
+		"email", customer.Email)
+
+	return &customer, nil
+}
+
+// UpdateCustomer changes the fields set in update and returns the updated customer.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) UpdateCustomer(ctx context.Context, customerID string, update CustomerUpdate, opts ...CallOption) (*Customer, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customer ID is required")
+	}
+	if update == (CustomerUpdate{}) {
+		return nil, fmt.Errorf("customer update has no fields set")
+	}
+
+	c.log(ctx).Info("Updating customer", "customer_id", customerID)
+
+	body := updateCustomerRequest{
+		CustomerID:     customerID,
+		CustomerUpdate: update,
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/customer/updatecustomer", nil, body, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to update customer", err,
+			"customer_id", customerID)
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+
+	var customer Customer
+	if err := c.decodeJSON(resp, &customer); err != nil {
+		c.log(ctx).Error("Failed to parse update customer response", err,
+			"customer_id", customerID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse customer: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully updated customer",
+		"customer_id", customer.ID,
+		"email", customer.Email)
+
+	return &customer, nil
+}
+
+// DeleteCustomer erases a customer and anonymizes their personal data, e.g. for a
+// GDPR data-subject request. It returns an error matching ErrNotFound for an
+// unknown customer and ErrReadOnlyMode when the client is read-only.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) DeleteCustomer(ctx context.Context, customerID string, opts ...CallOption) error {
+	if strings.TrimSpace(customerID) == "" {
+		return fmt.Errorf("customer ID is required")
+	}
+
+	c.log(ctx).Warn("Deleting customer", "customer_id", customerID)
+
+	body := deleteCustomerRequest{CustomerID: customerID}
+
+	if _, err := c.sendRequest(ctx, "POST", "/customer/deletecustomer", nil, body, opts...); err != nil {
+		c.log(ctx).Error("Failed to delete customer", err,
+			"customer_id", customerID)
+		return fmt.Errorf("failed to delete customer: %w", err)
+	}
+
+	c.log(ctx).Warn("Successfully deleted customer", "customer_id", customerID)
+
+	return nil
+}
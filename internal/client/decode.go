@@ -0,0 +1,195 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// isEmptyBody reports whether a response body carries no content, as with
+// 204 No Content or an empty 200
+func isEmptyBody(body []byte) bool {
+	return len(bytes.TrimSpace(body)) == 0
+}
+
+// unmarshalJSON is json.Unmarshal, or a decoder that rejects fields missing
+// from v when strict is set. The strict check also covers struct types with
+// their own UnmarshalJSON, such as Subscription, whose fields are checked
+// against the object they decode from.
+func unmarshalJSON(body []byte, v interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(body, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid character after top-level value")
+	}
+	return checkKnownFields(body, reflect.TypeOf(v))
+}
+
+// checkKnownFields returns an error for the first object key in data without a
+// matching field in typ, descending into nested objects and arrays. Values
+// that are not objects, such as a CBTime string, are not inspected.
+func checkKnownFields(data []byte, typ reflect.Type) error {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if data[0] != '{' || json.Unmarshal(data, &object) != nil {
+			return nil
+		}
+		fields := jsonFields(typ)
+		for key, value := range object {
+			fieldType, ok := lookupJSONField(fields, key)
+			if !ok {
+				return fmt.Errorf("json: unknown field %q", key)
+			}
+			if err := checkKnownFields(value, fieldType); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if data[0] != '{' || json.Unmarshal(data, &object) != nil {
+			return nil
+		}
+		for _, value := range object {
+			if err := checkKnownFields(value, typ.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if data[0] != '[' || json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		for _, item := range items {
+			if err := checkKnownFields(item, typ.Elem()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields maps the JSON names of a struct's fields, including those promoted
+// from embedded structs, to their types
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for promoted, promotedType := range jsonFields(embedded) {
+					if _, ok := fields[promoted]; !ok {
+						fields[promoted] = promotedType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupJSONField finds a field by JSON name, ignoring case like encoding/json
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if typ, ok := fields[key]; ok {
+		return typ, true
+	}
+	for name, typ := range fields {
+		if strings.EqualFold(name, key) {
+			return typ, true
+		}
+	}
+	return nil, false
+}
+
+// decodeJSON unmarshals a response body into v. An empty body is treated as
+// success and leaves v at its zero value. With StrictDecoding set, fields
+// unknown to v are an error. Failures are returned as a *ParseError.
+func (c *BaseClient) decodeJSON(resp *Response, v interface{}) error {
+	if isEmptyBody(resp.Body) {
+		return nil
+	}
+	if err := unmarshalJSON(resp.Body, v, c.config.StrictDecoding); err != nil {
+		return resp.parseError(err)
+	}
+	return nil
+}
+
+// decodeList unmarshals a response that should be a JSON array. Cleverbridge
+// answers some unknown lookups with null or an object instead of an empty array,
+// so anything that is valid JSON but not an array yields an empty slice.
+// An object with an "items" array is unwrapped. Only malformed JSON, or an
+// unknown field when strict is set, is an error, returned as a *ParseError.
+func decodeList[T any](resp *Response, strict bool) ([]T, error) {
+	items, err := decodeListBody[T](resp.Body, strict)
+	if err != nil {
+		return nil, resp.parseError(err)
+	}
+	return items, nil
+}
+
+func decodeListBody[T any](body []byte, strict bool) ([]T, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return []T{}, nil
+	}
+	if !json.Valid(body) {
+		var items []T
+		return nil, json.Unmarshal(body, &items)
+	}
+
+	switch body[0] {
+	case '[':
+		items := []T{}
+		if err := unmarshalJSON(body, &items, strict); err != nil {
+			return nil, err
+		}
+		return items, nil
+	case '{':
+		var envelope struct {
+			Items json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(body, &envelope); err == nil && bytes.HasPrefix(envelope.Items, []byte("[")) {
+			items := []T{}
+			if err := unmarshalJSON(envelope.Items, &items, strict); err != nil {
+				return nil, err
+			}
+			return items, nil
+		}
+	}
+	return []T{}, nil
+}
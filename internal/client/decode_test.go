@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestEmptyResponsesAreSuccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.Handler
+	}{
+		{"204 No Content", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})},
+		{"empty 200", jsonHandler(http.StatusOK, "")},
+		{"whitespace 200", jsonHandler(http.StatusOK, " \n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.handler)
+
+			subscription, err := c.CancelSubscription(context.Background(), "S1", "")
+			if err != nil {
+				t.Fatalf("CancelSubscription error: %v", err)
+			}
+			if subscription == nil || subscription.ID != "" {
+				t.Errorf("subscription = %+v, want the zero value", subscription)
+			}
+		})
+	}
+}
+
+func TestDecodeList(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"array", `[{"id":"S1"},{"id":"S2"}]`, []string{"S1", "S2"}},
+		{"empty array", `[]`, nil},
+		{"empty body", ``, nil},
+		{"null", `null`, nil},
+		{"object", `{"message":"no subscriptions"}`, nil},
+		{"items envelope", `{"items":[{"id":"S3"}],"totalCount":1}`, []string{"S3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := decodeList[Subscription](&Response{StatusCode: http.StatusOK, Body: []byte(tt.body)}, false)
+			if err != nil {
+				t.Fatalf("decodeList(%s) error: %v", tt.body, err)
+			}
+			if items == nil {
+				t.Fatalf("decodeList(%s) = nil, want a non-nil slice", tt.body)
+			}
+			if len(items) != len(tt.want) {
+				t.Fatalf("decodeList(%s) returned %d items, want %d", tt.body, len(items), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if items[i].ID != id {
+					t.Errorf("items[%d].ID = %q, want %q", i, items[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeListMalformed(t *testing.T) {
+	_, err := decodeList[Subscription](&Response{StatusCode: http.StatusOK, Body: []byte(`[{"id":`), path: "/x"}, false)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("error = %v, want a *ParseError", err)
+	}
+}
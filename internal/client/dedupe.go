@@ -0,0 +1,34 @@
+package client
+
+import "golang.org/x/sync/singleflight"
+
+// WithSingleflight shares one in-flight request among concurrent identical GET
+// calls, keyed by path, query and per-request headers. Every caller receives
+// its own copy of the response. The shared request runs under the context of
+// the caller that started it, so cancelling that call fails it for all
+// callers waiting on it. De-duplication is off unless this option is given.
+func WithSingleflight() Option {
+	return func(c *BaseClient) {
+		c.flight = &singleflight.Group{}
+	}
+}
+
+// flightResult carries a response alongside its error, so that error responses
+// still reach every caller sharing the request
+type flightResult struct {
+	resp *Response
+	err  error
+}
+
+// sendShared runs send once for all concurrent callers with the same key
+func (c *BaseClient) sendShared(key string, send func() (*Response, error)) (*Response, error) {
+	v, _, shared := c.flight.Do(key, func() (interface{}, error) {
+		resp, err := send()
+		return flightResult{resp: resp, err: err}, nil
+	})
+	result := v.(flightResult)
+	if shared && result.resp != nil {
+		return result.resp.clone(), result.err
+	}
+	return result.resp, result.err
+}
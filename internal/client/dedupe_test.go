@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleflightSharesConcurrentReads(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Write([]byte(`{"id":"S1","status":"active"}`))
+	}), WithSingleflight())
+
+	const callers = 100
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	subscriptions := make([]*Subscription, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		go func(i int) {
+			defer done.Done()
+			started.Done()
+			subscriptions[i], errs[i] = c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+		}(i)
+	}
+	started.Wait()
+	// Give every caller time to join the in-flight request before it completes
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if hits != 1 {
+		t.Errorf("server hit %d times, want 1", hits)
+	}
+	for i := range subscriptions {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: %v", i, errs[i])
+		}
+		if subscriptions[i].ID != "S1" {
+			t.Errorf("caller %d got %+v, want S1", i, subscriptions[i])
+		}
+	}
+	subscriptions[0].Status = StatusCancelled
+	if subscriptions[1].Status != StatusActive {
+		t.Error("callers share the decoded subscription, want a copy each")
+	}
+}
+
+func TestWithoutSingleflightReadsAreNotShared(t *testing.T) {
+	var hits int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(`{"id":"S1"}`))
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+		}()
+	}
+	wg.Wait()
+
+	if hits != 10 {
+		t.Errorf("server hit %d times, want 10", hits)
+	}
+}
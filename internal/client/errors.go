@@ -0,0 +1,191 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrNotFound is returned when the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized is returned when the API rejects the client credentials
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInvalidSignature is returned when a webhook signature does not match its payload
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrReadOnlyMode is returned for mutating requests when the client is configured as read-only
+	ErrReadOnlyMode = errors.New("client is in read-only mode")
+	// ErrClientClosed is returned for requests started after Shutdown was called
+	ErrClientClosed = errors.New("client is shut down")
+	// ErrCircuitOpen is returned without contacting the API while the circuit breaker is open
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+	// ErrResponseTooLarge is returned when a response body exceeds MaxResponseBytes
+	ErrResponseTooLarge = errors.New("response body too large")
+)
+
+// APIError is returned when the Cleverbridge API responds with an error status.
+// Use errors.As to inspect it.
+type APIError struct {
+	StatusCode       int
+	Message          string
+	RawBody          []byte
+	CleverbridgeCode string
+	// Errors holds the individual errors when the API reports several, e.g. one
+	// per invalid field
+	Errors []APISubError
+	// RequestID is the Cleverbridge request id, useful in support tickets
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.CleverbridgeCode != "":
+		return fmt.Sprintf("API error: status %d, %s: %s", e.StatusCode, e.CleverbridgeCode, e.Message)
+	case e.Message != "":
+		return fmt.Sprintf("API error: status %d: %s", e.StatusCode, e.Message)
+	default:
+		return fmt.Sprintf("API error: status %d, body: %s", e.StatusCode, string(e.RawBody))
+	}
+}
+
+// APISubError is one entry of an {errors: [{code, message}]} error response
+type APISubError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Is lets errors.Is match a 404 APIError against ErrNotFound and a 401 against ErrUnauthorized
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
+
+// parseErrorSnippetBytes is how much of an undecodable body a ParseError keeps
+const parseErrorSnippetBytes = 200
+
+// ParseError is returned when a response body cannot be decoded, e.g. an HTML
+// page from a gateway or CDN where JSON was expected. Snippet holds the start of
+// the body with sensitive fields redacted.
+type ParseError struct {
+	Endpoint   string
+	StatusCode int
+	Snippet    string
+	Err        error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid response from %s (status %d): %v, body: %q", e.Endpoint, e.StatusCode, e.Err, e.Snippet)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError wraps a decode failure of this response in a ParseError
+func (r *Response) parseError(err error) error {
+	return &ParseError{
+		Endpoint:   r.path,
+		StatusCode: r.StatusCode,
+		Snippet:    truncate(redactJSON(bytes.TrimSpace(r.Body)), parseErrorSnippetBytes),
+		Err:        err,
+	}
+}
+
+// PaymentDeclinedError is returned when a new payment method is declined.
+// Reason holds the decline reason reported by Cleverbridge.
+type PaymentDeclinedError struct {
+	Reason string
+	Err    *APIError
+}
+
+func (e *PaymentDeclinedError) Error() string {
+	return fmt.Sprintf("payment method declined: %s", e.Reason)
+}
+
+func (e *PaymentDeclinedError) Unwrap() error {
+	return e.Err
+}
+
+// asPaymentDeclined turns an API error reporting a declined payment, by status
+// 402 or a decline error code, into a PaymentDeclinedError
+func asPaymentDeclined(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.StatusCode != http.StatusPaymentRequired &&
+		!strings.Contains(strings.ToUpper(apiErr.CleverbridgeCode), "DECLINE") {
+		return err
+	}
+
+	reason := apiErr.Message
+	if reason == "" {
+		reason = apiErr.CleverbridgeCode
+	}
+	if reason == "" {
+		reason = "no reason given"
+	}
+	return &PaymentDeclinedError{Reason: reason, Err: apiErr}
+}
+
+// maxPlainErrorLength bounds plain-text error bodies taken as the message;
+// anything longer is likely an HTML error page and is left in RawBody
+const maxPlainErrorLength = 512
+
+// parseAPIError builds an APIError from a Cleverbridge error response. The
+// known body shapes are tried in order: the {error, message} envelope, the
+// {errors: [{code, message}]} list, a JSON string and plain text. Other
+// bodies are only kept in RawBody.
+func parseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		RawBody:    body,
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return apiErr
+	}
+
+	var cbErr cleverbridgeError
+	if err := json.Unmarshal(trimmed, &cbErr); err == nil {
+		apiErr.CleverbridgeCode = cbErr.Error
+		apiErr.Message = cbErr.Message
+		apiErr.Errors = cbErr.Errors
+		if len(cbErr.Errors) > 0 {
+			if apiErr.CleverbridgeCode == "" {
+				apiErr.CleverbridgeCode = cbErr.Errors[0].Code
+			}
+			if apiErr.Message == "" {
+				messages := make([]string, 0, len(cbErr.Errors))
+				for _, subErr := range cbErr.Errors {
+					if subErr.Message != "" {
+						messages = append(messages, subErr.Message)
+					}
+				}
+				apiErr.Message = strings.Join(messages, "; ")
+			}
+		}
+		return apiErr
+	}
+
+	var message string
+	if err := json.Unmarshal(trimmed, &message); err == nil {
+		apiErr.Message = message
+		return apiErr
+	}
+
+	if !json.Valid(trimmed) && trimmed[0] != '<' && len(trimmed) <= maxPlainErrorLength && utf8.Valid(trimmed) {
+		apiErr.Message = string(trimmed)
+	}
+	return apiErr
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		target error
+		run    func(t *testing.T) error
+	}{
+		{"404 is ErrNotFound", ErrNotFound, func(t *testing.T) error {
+			c := newTestClient(t, jsonHandler(http.StatusNotFound, `{"message":"no such subscription"}`))
+			_, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+			return err
+		}},
+		{"empty customer is ErrNotFound", ErrNotFound, func(t *testing.T) error {
+			c := newTestClient(t, jsonHandler(http.StatusOK, `{}`))
+			_, err := c.GetCustomer(ctx, "C1")
+			return err
+		}},
+		{"401 is ErrUnauthorized", ErrUnauthorized, func(t *testing.T) error {
+			c := newTestClient(t, jsonHandler(http.StatusUnauthorized, `{"message":"bad credentials"}`))
+			_, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+			return err
+		}},
+		{"bad signature is ErrInvalidSignature", ErrInvalidSignature, func(t *testing.T) error {
+			return VerifyWebhookSignature([]byte(`{}`), "sha256=00", "secret")
+		}},
+		{"read-only POST is ErrReadOnlyMode", ErrReadOnlyMode, func(t *testing.T) error {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("request sent in read-only mode")
+			}), func(c *BaseClient) { c.config.ReadOnly = true })
+			_, err := c.CancelSubscription(ctx, "S1", "")
+			return err
+		}},
+		{"request after Shutdown is ErrClientClosed", ErrClientClosed, func(t *testing.T) error {
+			c := newTestClient(t, jsonHandler(http.StatusOK, `{"id":"S1"}`))
+			if err := c.Shutdown(ctx); err != nil {
+				t.Fatal(err)
+			}
+			_, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+			return err
+		}},
+		{"open breaker is ErrCircuitOpen", ErrCircuitOpen, func(t *testing.T) error {
+			c := newTestClient(t, jsonHandler(http.StatusServiceUnavailable, `{}`),
+				func(c *BaseClient) { c.config.CircuitBreakerThreshold = 1 })
+			c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+			_, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(t)
+			if !errors.Is(err, tt.target) {
+				t.Errorf("error = %v, want errors.Is(err, %v)", err, tt.target)
+			}
+		})
+	}
+}
+
+func TestAPIErrorIsOnlyMatchesItsStatus(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusBadRequest})
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) {
+		t.Errorf("a 400 APIError matched a 404/401 sentinel")
+	}
+}
+
+func TestParseAPIErrorEnvelopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode string
+		wantMsg  string
+		wantSubs []APISubError
+	}{
+		{"error envelope", `{"error":"invalid_subscription","message":"Subscription is cancelled"}`,
+			"invalid_subscription", "Subscription is cancelled", nil},
+		{"errors list", `{"errors":[{"code":"quantity","message":"Quantity must be positive"},{"code":"currency","message":"Unknown currency"}]}`,
+			"quantity", "Quantity must be positive; Unknown currency",
+			[]APISubError{{"quantity", "Quantity must be positive"}, {"currency", "Unknown currency"}}},
+		{"JSON string", `"Subscription not found"`, "", "Subscription not found", nil},
+		{"plain text", "Service Unavailable\n", "", "Service Unavailable", nil},
+		{"HTML page", "<html><body>Bad Gateway</body></html>", "", "", nil},
+		{"empty body", "", "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := parseAPIError(http.StatusBadRequest, []byte(tt.body))
+			if apiErr.CleverbridgeCode != tt.wantCode {
+				t.Errorf("CleverbridgeCode = %q, want %q", apiErr.CleverbridgeCode, tt.wantCode)
+			}
+			if apiErr.Message != tt.wantMsg {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMsg)
+			}
+			if !reflect.DeepEqual(apiErr.Errors, tt.wantSubs) {
+				t.Errorf("Errors = %+v, want %+v", apiErr.Errors, tt.wantSubs)
+			}
+			if string(apiErr.RawBody) != tt.body {
+				t.Errorf("RawBody = %q, want %q", apiErr.RawBody, tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats for ExportSubscriptions
+const (
+	ExportCSV       = "csv"
+	ExportJSONLines = "jsonl"
+)
+
+const exportPageSize = 100
+
+var subscriptionCSVHeader = []string{
+	"id", "status", "plan", "customer_id", "product_id", "quantity", "purchase_id",
+	"amount", "currency", "billing_cycle", "created_at", "next_billing_date", "current_period_end",
+}
+
+// ExportSubscriptions writes all of a customer's subscriptions to w as CSV, with
+// a header row, or as JSON lines. Pages are fetched and written one at a time,
+// so the full list is never held in memory.
+func (c *BaseClient) ExportSubscriptions(ctx context.Context, customerID string, w io.Writer, format string) error {
+	if customerID == "" {
+		return fmt.Errorf("customer ID is required")
+	}
+
+	var write func(Subscription) error
+	var flush func() error
+	switch strings.ToLower(format) {
+	case ExportCSV:
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(subscriptionCSVHeader); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		write = func(s Subscription) error {
+			return csvWriter.Write(subscriptionCSVRecord(s))
+		}
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	case ExportJSONLines, "jsonlines", "ndjson":
+		encoder := json.NewEncoder(w)
+		write = func(s Subscription) error {
+			return encoder.Encode(s)
+		}
+		flush = func() error { return nil }
+	default:
+		return fmt.Errorf("unsupported export format %q, expected %q or %q", format, ExportCSV, ExportJSONLines)
+	}
+
+	c.log(ctx).Info("Exporting subscriptions",
+		"customer_id", customerID,
+		"format", format)
+
+	count := 0
+	err := c.IterateSubscriptionsForCustomer(ctx, customerID, exportPageSize, func(s Subscription) error {
+		if err := write(s); err != nil {
+			return fmt.Errorf("failed to write subscription %s: %w", s.ID, err)
+		}
+		count++
+		return nil
+	})
+	if flushErr := flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("failed to write export: %w", flushErr)
+	}
+	if err != nil {
+		c.log(ctx).Error("Failed to export subscriptions", err,
+			"customer_id", customerID,
+			"exported_count", count)
+		return fmt.Errorf("failed to export subscriptions: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully exported subscriptions",
+		"customer_id", customerID,
+		"exported_count", count)
+
+	return nil
+}
+
+func subscriptionCSVRecord(s Subscription) []string {
+	return []string{
+		s.ID,
+		string(s.Status),
+		s.Plan,
+		s.CustomerID,
+		s.ProductID,
+		strconv.Itoa(s.Quantity),
+		s.PurchaseID,
+		s.Amount.String(),
+		s.Currency,
+		string(s.BillingCycle),
+		formatExportTime(s.CreatedAt),
+		formatExportTime(s.NextBillingDate),
+		formatExportTime(s.CurrentPeriodEnd),
+	}
+}
+
+func formatExportTime(t CBTime) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// flexString is a string that also decodes from a JSON number, for ids the API
+// sometimes sends as numbers ("id": 18577447) and sometimes as strings. The
+// number is kept in its literal form, so large ids don't lose precision. It is
+// only used while decoding; the exported types keep plain string fields.
+type flexString string
+
+func (f *flexString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*f = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*f = flexString(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid id %s: expected a string or number", string(data))
+	}
+	*f = flexString(n.String())
+	return nil
+}
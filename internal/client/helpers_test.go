@@ -0,0 +1,50 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// nopLogger discards all log output in tests
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{})        {}
+func (nopLogger) Info(string, ...interface{})         {}
+func (nopLogger) Warn(string, ...interface{})         {}
+func (nopLogger) Error(string, error, ...interface{}) {}
+
+// newTestClient returns a client talking to an httptest server running handler.
+// Retries are disabled unless overridden with WithRetries.
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *BaseClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := &CleverbridgeConfig{
+		ClientID:       "test-client-id",
+		ClientSecret:   "test-client-secret",
+		BaseURL:        server.URL,
+		MaxRetries:     -1,
+		RetryBaseDelay: time.Millisecond,
+	}
+	opts = append([]Option{WithLogger(nopLogger{})}, opts...)
+
+	c, err := NewBaseClient(config, opts...)
+	if err != nil {
+		t.Fatalf("NewBaseClient: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// jsonHandler answers every request with the given status and body
+func jsonHandler(statusCode int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	})
+}
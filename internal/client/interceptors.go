@@ -0,0 +1,50 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestInterceptor is called with every outgoing request, after the client has
+// set its own headers. Returning an error aborts the request.
+type RequestInterceptor func(*http.Request) error
+
+// ResponseInterceptor is called with every response before its body is read.
+// It must not consume the body. Returning an error aborts the request.
+type ResponseInterceptor func(*http.Response) error
+
+// errInterceptorAborted marks errors returned by interceptors, which are never retried
+var errInterceptorAborted = errors.New("aborted by interceptor")
+
+// WithRequestInterceptor registers request interceptors. Interceptors run in registration order.
+func WithRequestInterceptor(interceptors ...RequestInterceptor) Option {
+	return func(c *BaseClient) {
+		c.requestInterceptors = append(c.requestInterceptors, interceptors...)
+	}
+}
+
+// WithResponseInterceptor registers response interceptors. Interceptors run in registration order.
+func WithResponseInterceptor(interceptors ...ResponseInterceptor) Option {
+	return func(c *BaseClient) {
+		c.responseInterceptors = append(c.responseInterceptors, interceptors...)
+	}
+}
+
+func (c *BaseClient) runRequestInterceptors(req *http.Request) error {
+	for _, intercept := range c.requestInterceptors {
+		if err := intercept(req); err != nil {
+			return fmt.Errorf("request %w: %w", errInterceptorAborted, err)
+		}
+	}
+	return nil
+}
+
+func (c *BaseClient) runResponseInterceptors(resp *http.Response) error {
+	for _, intercept := range c.responseInterceptors {
+		if err := intercept(resp); err != nil {
+			return fmt.Errorf("response %w: %w", errInterceptorAborted, err)
+		}
+	}
+	return nil
+}
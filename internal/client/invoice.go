@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+func (c *BaseClient) GetInvoice(ctx context.Context, invoiceID string, opts ...CallOption) (*Invoice, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("invoice ID is required")
+	}
+
+	c.log(ctx).Info("Getting invoice", "invoice_id", invoiceID)
+
+	queryParams := query{}.set("invoiceId", invoiceID)
+
+	resp, err := c.sendRequest(ctx, "GET", "/invoice/getinvoice", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get invoice", err,
+			"invoice_id", invoiceID)
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	var invoice Invoice
+	if err := c.decodeJSON(resp, &invoice); err != nil {
+		c.log(ctx).Error("Failed to parse invoice response", err,
+			"invoice_id", invoiceID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse invoice: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved invoice",
+		"invoice_id", invoice.ID,
+		"purchase_id", invoice.PurchaseID,
+		"status", invoice.Status)
+
+	return &invoice, nil
+}
+
+// DownloadInvoicePDF returns the invoice as a PDF document. The body is returned
+// as-is and is not JSON-decoded.
+func (c *BaseClient) DownloadInvoicePDF(ctx context.Context, invoiceID string, opts ...CallOption) ([]byte, error) {
+	if invoiceID == "" {
+		return nil, fmt.Errorf("invoice ID is required")
+	}
+
+	c.log(ctx).Info("Downloading invoice PDF", "invoice_id", invoiceID)
+
+	resp, err := c.do(ctx, Request{
+		Method:      "GET",
+		Path:        "/invoice/getinvoicepdf",
+		QueryParams: query{}.set("invoiceId", invoiceID),
+		Headers:     map[string]string{"Accept": "application/pdf"},
+	}, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to download invoice PDF", err,
+			"invoice_id", invoiceID)
+		return nil, fmt.Errorf("failed to download invoice PDF: %w", err)
+	}
+
+	if len(resp.Body) == 0 {
+		return nil, fmt.Errorf("invoice %s: empty PDF response", invoiceID)
+	}
+
+	c.log(ctx).Info("Successfully downloaded invoice PDF",
+		"invoice_id", invoiceID,
+		"size", len(resp.Body))
+
+	return resp.Body, nil
+}
+
+// GetUpcomingInvoice previews the next charge of a subscription with its line
+// items, subtotal, tax breakdown and total in the subscription's currency. It is
+// read-only and does not charge the customer.
+func (c *BaseClient) GetUpcomingInvoice(ctx context.Context, subscriptionID string, opts ...CallOption) (*Invoice, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+
+	c.log(ctx).Info("Getting upcoming invoice", "subscription_id", subscriptionID)
+
+	queryParams := query{}.set("subscriptionId", subscriptionID)
+
+	resp, err := c.sendRequest(ctx, "GET", "/subscription/getupcominginvoice", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get upcoming invoice", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to get upcoming invoice: %w", err)
+	}
+
+	var invoice Invoice
+	if err := c.decodeJSON(resp, &invoice); err != nil {
+		c.log(ctx).Error("Failed to parse upcoming invoice response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse upcoming invoice: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved upcoming invoice",
+		"subscription_id", subscriptionID,
+		"total", invoice.Total,
+		"tax", invoice.Tax,
+		"currency", invoice.Currency)
+
+	return &invoice, nil
+}
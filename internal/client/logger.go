@@ -0,0 +1,280 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel is the minimum severity a Logger writes
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LogLevel(%d)", int(l))
+	}
+}
+
+// ParseLogLevel converts a level name such as "info" into a LogLevel
+func ParseLogLevel(name string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// Logger is the logging interface used by the client. Fields are passed as
+// alternating key/value pairs.
+type Logger interface {
+	Debug(message string, fields ...interface{})
+	Info(message string, fields ...interface{})
+	Warn(message string, fields ...interface{})
+	Error(message string, err error, fields ...interface{})
+}
+
+// LogFormat selects how StdLogger renders log lines
+type LogFormat int
+
+const (
+	// FormatText writes lines like "INFO: message key=value key=value"
+	FormatText LogFormat = iota
+	// FormatJSON writes one JSON object per line
+	FormatJSON
+)
+
+// StdLogger is the built-in Logger writing text or JSON lines to stdout or a file.
+// It is safe for concurrent use; every line is written with a single Write call.
+type StdLogger struct {
+	mu      sync.Mutex
+	level   LogLevel
+	format  LogFormat
+	logFile io.Closer
+	writer  io.Writer
+	// shares counts clients using the logger besides its creator, see retain
+	shares int
+}
+
+// NewLogger creates a new logger with file support
+func NewLogger(level LogLevel, logFile string) *StdLogger {
+	var writer io.Writer = os.Stdout
+
+	if logFile != "" {
+		file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		if err != nil {
+			log.Printf("Failed to open log file %s: %v, using stdout", logFile, err)
+		} else {
+			writer = file
+			return &StdLogger{level: level, logFile: file, writer: writer}
+		}
+	}
+
+	return &StdLogger{level: level, writer: writer}
+}
+
+// NewWriterLogger creates a logger writing to w, e.g. a RotatingFile or a
+// lumberjack.Logger. The logger does not close w.
+func NewWriterLogger(level LogLevel, w io.Writer) *StdLogger {
+	return &StdLogger{level: level, writer: w}
+}
+
+// NewRotatingLogger creates a logger writing to a size-rotated log file, see RotatingFile
+func NewRotatingLogger(level LogLevel, logFile string, maxSize int64, maxBackups int) (*StdLogger, error) {
+	file, err := NewRotatingFile(logFile, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &StdLogger{level: level, logFile: file, writer: file}, nil
+}
+
+// SetLevel changes the minimum level written by the logger
+func (l *StdLogger) SetLevel(level LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+// SetFormat changes how log lines are rendered
+func (l *StdLogger) SetFormat(format LogFormat) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = format
+}
+
+// Enabled reports whether messages at the given level are written
+func (l *StdLogger) Enabled(level LogLevel) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return level >= l.level
+}
+
+// Close releases one reference to the logger. The log file is closed once the
+// creator and every client sharing the logger through WithLogger have closed it,
+// so closing one client does not cut off the others.
+func (l *StdLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.shares > 0 {
+		l.shares--
+		return nil
+	}
+	if l.logFile != nil {
+		err := l.logFile.Close()
+		l.logFile = nil
+		return err
+	}
+	return nil
+}
+
+// retain adds a reference for a client that shares the logger
+func (l *StdLogger) retain() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.shares++
+}
+
+// write formats and writes a single log line if the level is enabled
+func (l *StdLogger) write(level LogLevel, message string, err error, fields []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if level < l.level {
+		return
+	}
+
+	if l.format == FormatJSON {
+		io.WriteString(l.writer, formatJSONLine(level, message, err, fields)+"\n")
+		return
+	}
+
+	msg := fmt.Sprintf("%s: %s", level, message)
+	if err != nil {
+		msg += fmt.Sprintf(" - %v", err)
+	}
+	for i := 0; i < len(fields); i += 2 {
+		key, value := fieldPair(fields, i)
+		msg += fmt.Sprintf(" %s=%s", key, formatTextValue(value))
+	}
+	io.WriteString(l.writer, msg+"\n")
+}
+
+// fieldPair returns the key/value pair starting at fields[i]. A trailing key
+// without a value is reported under !BADKEY like log/slog does.
+func fieldPair(fields []interface{}, i int) (string, interface{}) {
+	if i+1 >= len(fields) {
+		return "!BADKEY", fields[i]
+	}
+	if key, ok := fields[i].(string); ok {
+		return key, fields[i+1]
+	}
+	return fmt.Sprint(fields[i]), fields[i+1]
+}
+
+// formatTextValue renders a value for key=value output, quoting it when it
+// contains spaces or quotes so the line stays parseable
+func formatTextValue(value interface{}) string {
+	s := fmt.Sprint(value)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// formatJSONLine renders a log entry as a single JSON object, keeping fields in order
+func formatJSONLine(level LogLevel, message string, err error, fields []interface{}) string {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONField(&buf, "time", time.Now().Format(time.RFC3339Nano), true)
+	writeJSONField(&buf, "level", level.String(), false)
+	writeJSONField(&buf, "msg", message, false)
+	if err != nil {
+		writeJSONField(&buf, "error", err.Error(), false)
+	}
+	for i := 0; i < len(fields); i += 2 {
+		key, value := fieldPair(fields, i)
+		if e, ok := value.(error); ok {
+			value = e.Error()
+		}
+		writeJSONField(&buf, key, value, false)
+	}
+	buf.WriteByte('}')
+	return buf.String()
+}
+
+func writeJSONField(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		buf.WriteByte(',')
+	}
+	keyData, _ := json.Marshal(key)
+	valueData, err := json.Marshal(value)
+	if err != nil {
+		valueData, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(keyData)
+	buf.WriteByte(':')
+	buf.Write(valueData)
+}
+
+// Debug logging of diagnostic details
+func (l *StdLogger) Debug(message string, fields ...interface{}) {
+	l.write(LevelDebug, message, nil, fields)
+}
+
+// Info logging information
+func (l *StdLogger) Info(message string, fields ...interface{}) {
+	l.write(LevelInfo, message, nil, fields)
+}
+
+// Warn logging of warnings
+func (l *StdLogger) Warn(message string, fields ...interface{}) {
+	l.write(LevelWarn, message, nil, fields)
+}
+
+// Error logging errors
+func (l *StdLogger) Error(message string, err error, fields ...interface{}) {
+	l.write(LevelError, message, err, fields)
+}
+
+// Json logging in JSON format at debug level (analog Perl Logger->json)
+func (l *StdLogger) Json(data map[string]interface{}) {
+	if l.Enabled(LevelDebug) {
+		jsonData, err := json.MarshalIndent(redactValue(data), "", "  ")
+		if err != nil {
+			l.Error("JSON marshaling failed", err)
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		io.WriteString(l.writer, "JSON LOG:\n"+string(jsonData)+"\n")
+	}
+}
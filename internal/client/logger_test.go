@@ -0,0 +1,48 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStdLoggerConcurrentWrites(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWriterLogger(LevelDebug, &buf)
+	logger.SetFormat(FormatJSON)
+
+	const goroutines, perGoroutine = 20, 50
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				switch i % 3 {
+				case 0:
+					logger.Info("request", "goroutine", g, "i", i)
+				case 1:
+					logger.Error("failed", fmt.Errorf("attempt %d", i), "goroutine", g)
+				default:
+					logger.SetLevel(LevelDebug)
+					logger.Debug("detail", "goroutine", g)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != goroutines*perGoroutine {
+		t.Fatalf("got %d log lines, want %d", len(lines), goroutines*perGoroutine)
+	}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("interleaved log line %q: %v", line, err)
+		}
+	}
+}
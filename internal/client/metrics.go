@@ -0,0 +1,31 @@
+package client
+
+import "time"
+
+// MetricsRecorder receives per-request instrumentation, e.g. to feed Prometheus
+// counters and histograms. Labels are the HTTP method, the endpoint path without
+// query string, and the status code (0 when no response was received).
+// Every HTTP attempt is recorded, including retries.
+type MetricsRecorder interface {
+	// RecordRequest is called once per attempt with its latency
+	RecordRequest(method, path string, statusCode int, duration time.Duration)
+	// RecordError is called for transport failures and error status codes
+	RecordError(method, path string, statusCode int)
+}
+
+// WithMetrics makes the client report request metrics to the given recorder
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(c *BaseClient) {
+		c.metrics = recorder
+	}
+}
+
+func (c *BaseClient) recordMetrics(method, path string, statusCode int, duration time.Duration, failed bool) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.RecordRequest(method, path, statusCode, duration)
+	if failed || statusCode >= 400 {
+		c.metrics.RecordError(method, path, statusCode)
+	}
+}
@@ -1,140 +1,505 @@
-package client
-
-import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"os"
-	"time"
-)
-
-type APIClient struct {
-	BaseClient
-	logger *Logger
-}
-
-type Subscription struct {
-	ID               string    `json:"id"`
-	Status           string    `json:"status"`
-	Plan             string    `json:"plan"`
-	CreatedAt        time.Time `json:"created_at"`
-	CustomerID       string    `json:"customer_id"`
-	ProductID        string    `json:"product_id"`
-	NextBillingDate  time.Time `json:"next_billing_date"`
-	CurrentPeriodEnd time.Time `json:"current_period_end"`
-	Amount           float64   `json:"amount"`
-	Currency         string    `json:"currency"`
-	BillingCycle     string    `json:"billing_cycle"`
-	PurchaseID       string    `json:"purchase_id"`
-}
-
-type BaseClient struct {
-	httpClient *http.Client
-	baseURL    string
-	config     *CleverbridgeConfig
-}
-
-type CleverbridgeConfig struct {
-	ClientID     string `yaml:"client_id"`
-	ClientSecret string `yaml:"client_secret"`
-	BaseURL      string `yaml:"base_url"`
-	Debug        bool   `yaml:"debug"`
-}
-
-type Request struct {
-	Method      string
-	Path        string
-	QueryParams map[string]string
-	Headers     map[string]string
-	Body        interface{}
-}
-
-type Response struct {
-	StatusCode int
-	Body       []byte
-	Headers    http.Header
-}
-
-type Logger struct {
-	debug   bool
-	logFile *os.File
-	writer  io.Writer
-}
-
-// NewLogger creates a new logger with file support
-func NewLogger(debug bool, logFile string) *Logger {
-	var writer io.Writer = os.Stdout
-
-	if logFile != "" {
-		file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-		if err != nil {
-			log.Printf("Failed to open log file %s: %v, using stdout", logFile, err)
-		} else {
-			writer = file
-			return &Logger{debug: debug, logFile: file, writer: writer}
-		}
-	}
-
-	return &Logger{debug: debug, writer: writer}
-}
-
-// Close closes the log file if it's open
-func (l *Logger) Close() error {
-	if l.logFile != nil {
-		return l.logFile.Close()
-	}
-	return nil
-}
-
-// Info logging information
-func (l *Logger) Info(message string, fields ...interface{}) {
-	if l.debug {
-		msg := fmt.Sprintf("INFO: %s", message)
-		if len(fields) > 0 {
-			msg += fmt.Sprintf(" %v", fields)
-		}
-		fmt.Fprintln(l.writer, msg)
-	}
-}
-
-// Warn logging of warnings
-func (l *Logger) Warn(message string, fields ...interface{}) {
-	msg := fmt.Sprintf("WARN: %s", message)
-	if len(fields) > 0 {
-		msg += fmt.Sprintf(" %v", fields)
-	}
-	fmt.Fprintln(l.writer, msg)
-}
-
-// Error logging errors
-func (l *Logger) Error(message string, err error, fields ...interface{}) {
-	msg := fmt.Sprintf("ERROR: %s", message)
-	if err != nil {
-		msg += fmt.Sprintf(" - %v", err)
-	}
-	if len(fields) > 0 {
-		msg += fmt.Sprintf(" %v", fields)
-	}
-	fmt.Fprintln(l.writer, msg)
-}
-
-// Json logging in JSON format (analog Perl Logger->json)
-func (l *Logger) Json(data map[string]interface{}) {
-	if l.debug {
-		jsonData, err := json.MarshalIndent(data, "", "  ")
-		if err != nil {
-			l.Error("JSON marshaling failed", err)
-			return
-		}
-		fmt.Fprintf(l.writer, "JSON LOG:\n%s\n", string(jsonData))
-	}
-}
-
-func (c *APIClient) Close() error {
-	if c.logger != nil {
-		return c.logger.Close()
-	}
-	return nil
-}
+package client
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+)
+
+// Subscription is a Cleverbridge subscription. JSON tags use the API's camelCase
+// field names; encoding/json matches them case-insensitively, so PascalCase
+// payloads decode as well. Ids are accepted as JSON strings or numbers.
+type Subscription struct {
+	ID               string             `json:"id"`
+	Status           SubscriptionStatus `json:"status"`
+	Plan             string             `json:"plan"`
+	CreatedAt        CBTime             `json:"createdAt"`
+	CustomerID       string             `json:"customerId"`
+	ProductID        string             `json:"productId"`
+	Quantity         int                `json:"quantity"`
+	NextBillingDate  CBTime             `json:"nextBillingDate"`
+	CurrentPeriodEnd CBTime             `json:"currentPeriodEnd"`
+	Amount           Money              `json:"amount"`
+	Currency         string             `json:"currency"`
+	BillingCycle     BillingCycle       `json:"billingCycle"`
+	PurchaseID       string             `json:"purchaseId"`
+
+	// Cancellation details, nil or empty unless the subscription was cancelled.
+	// CancelEffectiveDate is when access ends, which may be after CancelledAt.
+	CancelledAt         *CBTime `json:"cancelledAt,omitempty"`
+	CancelReason        string  `json:"cancelReason,omitempty"`
+	CancelEffectiveDate *CBTime `json:"cancelEffectiveDate,omitempty"`
+}
+
+// ExpandedSubscription is a subscription with its related objects embedded.
+// Customer and Product are nil unless they were requested with WithExpand.
+type ExpandedSubscription struct {
+	Subscription
+	Customer *Customer `json:"customer,omitempty"`
+	Product  *Product  `json:"product,omitempty"`
+}
+
+// UnmarshalJSON accepts the ids of a subscription as JSON numbers as well as
+// strings, as the API sends either. Numeric ids keep their literal form.
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	type plainSubscription Subscription
+	aux := struct {
+		*plainSubscription
+		ID         flexString `json:"id"`
+		CustomerID flexString `json:"customerId"`
+		ProductID  flexString `json:"productId"`
+		PurchaseID flexString `json:"purchaseId"`
+	}{
+		plainSubscription: (*plainSubscription)(s),
+		ID:                flexString(s.ID),
+		CustomerID:        flexString(s.CustomerID),
+		ProductID:         flexString(s.ProductID),
+		PurchaseID:        flexString(s.PurchaseID),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	s.ID = string(aux.ID)
+	s.CustomerID = string(aux.CustomerID)
+	s.ProductID = string(aux.ProductID)
+	s.PurchaseID = string(aux.PurchaseID)
+	return nil
+}
+
+// UnmarshalJSON decodes the subscription fields with Subscription.UnmarshalJSON,
+// which ExpandedSubscription would otherwise promote for the whole object,
+// dropping Customer and Product.
+func (e *ExpandedSubscription) UnmarshalJSON(data []byte) error {
+	if err := e.Subscription.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	var expanded struct {
+		Customer *Customer `json:"customer"`
+		Product  *Product  `json:"product"`
+	}
+	if err := json.Unmarshal(data, &expanded); err != nil {
+		return err
+	}
+	e.Customer = expanded.Customer
+	e.Product = expanded.Product
+	return nil
+}
+
+type SubscriptionEvent struct {
+	Type      SubscriptionEventType `json:"type"`
+	Code      string                `json:"-"`
+	Timestamp CBTime                `json:"timestamp"`
+	Detail    string                `json:"detail"`
+	Amount    Money                 `json:"amount"`
+}
+
+// SubscriptionEventType is the readable form of a Cleverbridge subscription event code
+type SubscriptionEventType string
+
+const (
+	EventCreated      SubscriptionEventType = "created"
+	EventRenewal      SubscriptionEventType = "renewal"
+	EventPlanChange   SubscriptionEventType = "plan_change"
+	EventCancellation SubscriptionEventType = "cancellation"
+	EventPause        SubscriptionEventType = "pause"
+	EventResume       SubscriptionEventType = "resume"
+	EventReactivation SubscriptionEventType = "reactivation"
+	EventUnknown      SubscriptionEventType = "unknown"
+)
+
+// subscriptionEventCodes maps Cleverbridge event codes to event types
+var subscriptionEventCodes = map[string]SubscriptionEventType{
+	"CREATED":      EventCreated,
+	"PURCHASE":     EventCreated,
+	"RENEWAL":      EventRenewal,
+	"REBILLING":    EventRenewal,
+	"UPGRADE":      EventPlanChange,
+	"DOWNGRADE":    EventPlanChange,
+	"PLANCHANGE":   EventPlanChange,
+	"CANCELLATION": EventCancellation,
+	"DEACTIVATION": EventCancellation,
+	"PAUSE":        EventPause,
+	"RESUME":       EventResume,
+	"REACTIVATION": EventReactivation,
+}
+
+// UnmarshalJSON keeps the raw event code and maps it onto a SubscriptionEventType
+func (e *SubscriptionEvent) UnmarshalJSON(data []byte) error {
+	type rawEvent struct {
+		Type      string `json:"type"`
+		Timestamp CBTime `json:"timestamp"`
+		Detail    string `json:"detail"`
+		Amount    Money  `json:"amount"`
+	}
+
+	var raw rawEvent
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*e = SubscriptionEvent{
+		Type:      parseSubscriptionEventType(raw.Type),
+		Code:      raw.Type,
+		Timestamp: raw.Timestamp,
+		Detail:    raw.Detail,
+		Amount:    raw.Amount,
+	}
+	return nil
+}
+
+func parseSubscriptionEventType(code string) SubscriptionEventType {
+	normalized := strings.ToUpper(strings.NewReplacer("_", "", "-", "", " ", "").Replace(code))
+	if eventType, ok := subscriptionEventCodes[normalized]; ok {
+		return eventType
+	}
+	return EventUnknown
+}
+
+type Customer struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Country   string `json:"country"`
+	Currency  string `json:"currency"`
+	CreatedAt CBTime `json:"createdAt"`
+}
+
+// CustomerUpdate holds the customer fields to change. Nil fields are not sent,
+// so they keep their current value.
+type CustomerUpdate struct {
+	Email     *string `json:"email,omitempty"`
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+	Country   *string `json:"country,omitempty"`
+}
+
+type Purchase struct {
+	ID           string         `json:"id"`
+	CustomerID   string         `json:"customerId"`
+	Items        []PurchaseItem `json:"items"`
+	Total        Money          `json:"total"`
+	Currency     string         `json:"currency"`
+	PurchaseDate CBTime         `json:"purchaseDate"`
+	Status       string         `json:"status"`
+}
+
+type PurchaseItem struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice Money  `json:"unitPrice"`
+}
+
+type Refund struct {
+	ID         string `json:"id"`
+	PurchaseID string `json:"purchaseId"`
+	Amount     Money  `json:"amount"`
+	Status     string `json:"status"`
+	CreatedAt  CBTime `json:"createdAt"`
+}
+
+type Invoice struct {
+	ID             string        `json:"id"`
+	Number         string        `json:"invoiceNumber"`
+	PurchaseID     string        `json:"purchaseId"`
+	SubscriptionID string        `json:"subscriptionId"`
+	CustomerID     string        `json:"customerId"`
+	Lines          []InvoiceLine `json:"lineItems"`
+	Subtotal       Money         `json:"subtotal"`
+	Tax            Money         `json:"tax"`
+	TaxLines       []TaxLine     `json:"taxLines"`
+	Total          Money         `json:"total"`
+	Currency       string        `json:"currency"`
+	InvoiceDate    CBTime        `json:"invoiceDate"`
+	Status         string        `json:"status"`
+}
+
+type InvoiceLine struct {
+	Description string `json:"description"`
+	ProductID   string `json:"productId"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   Money  `json:"unitPrice"`
+	Amount      Money  `json:"amount"`
+}
+
+// TaxLine is one tax applied to an invoice, e.g. VAT. Rate is a percentage.
+type TaxLine struct {
+	Name   string  `json:"name"`
+	Rate   float64 `json:"rate"`
+	Amount Money   `json:"amount"`
+}
+
+type Product struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	BasePrice   Money  `json:"basePrice"`
+	Currency    string `json:"currency"`
+	Recurring   bool   `json:"recurring"`
+}
+
+// CreateSubscriptionRequest describes a new subscription purchase for CreateSubscription
+type CreateSubscriptionRequest struct {
+	CustomerID   string `json:"customerId"`
+	ProductID    string `json:"productId"`
+	Quantity     int    `json:"quantity"`
+	Currency     string `json:"currency"`
+	PaymentToken string `json:"paymentToken"`
+}
+
+type cancelSubscriptionRequest struct {
+	SubscriptionID     string `json:"subscriptionId"`
+	CancellationReason string `json:"cancellationReason,omitempty"`
+}
+
+type reactivateSubscriptionRequest struct {
+	SubscriptionID string `json:"subscriptionId"`
+}
+
+type changeNextBillingDateRequest struct {
+	SubscriptionID  string `json:"subscriptionId"`
+	NextBillingDate string `json:"nextBillingDate"`
+}
+
+type changeSubscriptionPlanRequest struct {
+	SubscriptionID string `json:"subscriptionId"`
+	PlanID         string `json:"planId"`
+	Prorate        bool   `json:"prorate"`
+}
+
+type changeSubscriptionQuantityRequest struct {
+	SubscriptionID string `json:"subscriptionId"`
+	Quantity       int    `json:"quantity"`
+	Prorate        bool   `json:"prorate"`
+}
+
+type changeSubscriptionPaymentMethodRequest struct {
+	SubscriptionID string `json:"subscriptionId"`
+	PaymentToken   string `json:"paymentToken"`
+}
+
+type refundPurchaseRequest struct {
+	PurchaseID string `json:"purchaseId"`
+	// Amount is omitted for a full refund
+	Amount *Money `json:"amount,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type updateCustomerRequest struct {
+	CustomerID string `json:"customerId"`
+	CustomerUpdate
+}
+
+type deleteCustomerRequest struct {
+	CustomerID string `json:"customerId"`
+}
+
+type BaseClient struct {
+	httpClient *http.Client
+	baseURL    string
+	config     *CleverbridgeConfig
+	logger     Logger
+	rawLogger  Logger
+	userAgent  string
+	limiter    *rate.Limiter
+	metrics    MetricsRecorder
+	rootCAs    *x509.CertPool
+	logWriter  io.Writer
+
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+	cache                *responseCache
+	auth                 authStrategy
+	breaker              *circuitBreaker
+	converter            CurrencyConverter
+	flight               *singleflight.Group
+	accessLog            AccessLogger
+	etags                *etagStore
+	clock                Clock
+	rateLimit            rateLimitTracker
+
+	// lifecycleMu guards shuttingDown; inFlight counts running requests for Shutdown
+	lifecycleMu  sync.Mutex
+	shuttingDown bool
+	inFlight     sync.WaitGroup
+	closeOnce    sync.Once
+	closeErr     error
+}
+
+// cleverbridgeError is the error envelope returned by the Cleverbridge API
+type cleverbridgeError struct {
+	Error   string        `json:"error"`
+	Message string        `json:"message"`
+	Errors  []APISubError `json:"errors"`
+}
+
+type CleverbridgeConfig struct {
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	BaseURL      string `yaml:"base_url"`
+	Debug        bool   `yaml:"debug"`
+
+	// Locale is sent as Accept-Language, e.g. "de-DE", to get localized error
+	// messages and product names. Empty leaves the API default. WithLocale
+	// overrides it per call.
+	Locale string `yaml:"locale"`
+
+	// PathPrefix is prepended to every endpoint path, e.g. "/v2" for a versioned
+	// API. Empty by default.
+	PathPrefix string `yaml:"path_prefix"`
+
+	// AuthMode is basic (default), sending the client credentials with every
+	// request, or oauth2, exchanging them at TokenURL for a bearer token that is
+	// cached and refreshed before it expires
+	AuthMode string `yaml:"auth_mode"`
+	// TokenURL is the OAuth2 token endpoint, required for auth_mode oauth2
+	TokenURL string `yaml:"token_url"`
+
+	// LogLevel is one of debug, info, warn or error (default warn). Debug: true forces debug.
+	LogLevel string `yaml:"log_level"`
+	// LogFormat is text (default) or json
+	LogFormat string `yaml:"log_format"`
+	// LogFile is appended to instead of writing logs to stdout
+	LogFile string `yaml:"log_file"`
+	// LogMaxSizeMB rotates LogFile once it reaches this size; zero disables rotation
+	LogMaxSizeMB int `yaml:"log_max_size_mb"`
+	// LogMaxBackups is the number of rotated log files to keep
+	LogMaxBackups int `yaml:"log_max_backups"`
+	// AccessLog writes one line per HTTP attempt with time, method, path, status,
+	// duration_ms, latency_bucket and bytes to the log, whatever LogLevel is. Use WithAccessLogger
+	// to send the entries elsewhere.
+	AccessLog bool `yaml:"access_log"`
+	// MaxLogBodyBytes truncates request and response bodies in logs to this many
+	// bytes (default 4096, negative disables truncation)
+	MaxLogBodyBytes int `yaml:"max_log_body_bytes"`
+
+	// HTTPTimeout bounds each HTTP request (default 30s). A deadline on the
+	// caller's context takes precedence over it.
+	HTTPTimeout time.Duration `yaml:"http_timeout"`
+	// EndpointTimeouts overrides HTTPTimeout for endpoints whose path, relative
+	// to PathPrefix, starts with the key, e.g. "/subscription/export": 1m for a
+	// slow endpoint. The longest matching prefix wins.
+	EndpointTimeouts map[string]time.Duration `yaml:"endpoint_timeouts"`
+	// DefaultRequestTimeout bounds a whole call including retries when the
+	// caller's context has no deadline. Zero leaves calls unbounded.
+	DefaultRequestTimeout time.Duration `yaml:"default_request_timeout"`
+
+	// MaxResponseBytes caps the size of a response body, after decompression
+	// (default 32 MiB, negative disables the limit). Larger responses fail with
+	// ErrResponseTooLarge.
+	MaxResponseBytes int64 `yaml:"max_response_bytes"`
+
+	// ProxyURL routes requests through an HTTP(S) proxy, honoring NO_PROXY.
+	// When empty the HTTP_PROXY/HTTPS_PROXY environment variables are used.
+	// Ignored when a custom HTTP client is supplied with WithHTTPClient.
+	ProxyURL string `yaml:"proxy_url"`
+
+	// CAFile adds the PEM certificates in this file to the system roots, e.g. for a
+	// gateway with a private CA. Use WithRootCAs to supply a pool programmatically.
+	CAFile string `yaml:"ca_file"`
+	// MinTLSVersion is 1.2 (default) or 1.3
+	MinTLSVersion string `yaml:"min_tls_version"`
+	// InsecureSkipVerify disables TLS certificate verification. Never enable it
+	// outside local testing.
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+
+	// Connection pool tuning; zero keeps Go's defaults. All requests go to one host,
+	// so MaxIdleConnsPerHost (Go default 2) is the one that matters: set it to about
+	// the number of concurrent requests (e.g. MaxConcurrency) so connections are
+	// reused instead of opened and closed under load. MaxIdleConns (default 100)
+	// caps idle connections overall and IdleConnTimeout (default 90s) closes idle
+	// ones; lower it if a proxy or load balancer drops idle connections sooner.
+	// Ignored when a custom HTTP client is supplied with WithHTTPClient.
+	MaxIdleConns        int           `yaml:"max_idle_conns"`
+	MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host"`
+	IdleConnTimeout     time.Duration `yaml:"idle_conn_timeout"`
+
+	// MaxRetries is the number of retries for transient errors (default 3, at most 10,
+	// negative disables retries)
+	MaxRetries int `yaml:"max_retries"`
+	// RetryBaseDelay is the initial backoff delay, doubled on every attempt up to 30s
+	// (default 200ms)
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay"`
+	// RetryNonIdempotent allows requests other than GET/HEAD to be retried as well,
+	// like WithRetryUnsafe on every call
+	RetryNonIdempotent bool `yaml:"retry_non_idempotent"`
+
+	// RequestsPerSecond enables client-side rate limiting when greater than zero
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst is the number of requests allowed at once by the rate limiter (default 1)
+	Burst int `yaml:"burst"`
+
+	// CircuitBreakerThreshold opens the circuit breaker after this many consecutive
+	// failed attempts (network errors or 5xx) within CircuitBreakerWindow (default
+	// 1m). While open, requests fail fast with ErrCircuitOpen for
+	// CircuitBreakerCooldown (default 30s), then a single request probes whether
+	// the API has recovered. Zero disables the breaker.
+	CircuitBreakerThreshold int           `yaml:"circuit_breaker_threshold"`
+	CircuitBreakerWindow    time.Duration `yaml:"circuit_breaker_window"`
+	CircuitBreakerCooldown  time.Duration `yaml:"circuit_breaker_cooldown"`
+
+	// MaxConcurrency bounds the parallel requests of bulk methods such as GetSubscriptions
+	// when the call does not set its own limit (default 8)
+	MaxConcurrency int `yaml:"max_concurrency"`
+
+	// ReadOnly blocks every request except GET and HEAD. Blocked requests are
+	// logged at warn level and fail with ErrReadOnlyMode without being sent.
+	ReadOnly bool `yaml:"read_only"`
+
+	// StrictDecoding makes responses with fields the client does not know about
+	// fail to decode, to surface API schema drift early, e.g. in a test environment
+	StrictDecoding bool `yaml:"strict_decoding"`
+}
+
+// logLevel maps Debug and LogLevel onto the logger level. An unknown
+// LogLevel falls back to info.
+func (c *CleverbridgeConfig) logLevel() LogLevel {
+	if c.Debug {
+		return LevelDebug
+	}
+	if c.LogLevel == "" {
+		return LevelWarn
+	}
+	level, _ := ParseLogLevel(c.LogLevel)
+	return level
+}
+
+// Request describes a raw API call for BaseClient.Do. Body is encoded as JSON
+// unless it is already a []byte.
+type Request struct {
+	Method      string
+	Path        string
+	QueryParams map[string]string
+	Headers     map[string]string
+	Body        interface{}
+}
+
+type Response struct {
+	StatusCode int
+	Body       []byte
+	Headers    http.Header
+
+	// path is the endpoint the response came from, for error reporting
+	path string
+}
+
+// Close closes the client's log file, if any. A logger shared through WithLogger
+// stays open until every client using it and its creator have closed it. Use
+// Shutdown to wait for requests that are still in flight first.
+func (c *BaseClient) Close() error {
+	c.closeOnce.Do(func() {
+		if closer, ok := c.rawLogger.(io.Closer); ok {
+			c.closeErr = closer.Close()
+		}
+	})
+	return c.closeErr
+}
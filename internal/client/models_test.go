@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSubscriptionFieldNames decodes a subscription in the shape returned by
+// /subscription/getsubscription, so a renamed struct tag shows up as a zero field
+func TestSubscriptionFieldNames(t *testing.T) {
+	body, err := os.ReadFile("testdata/subscription.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Subscription
+	if err := unmarshalJSON(body, &got, false); err != nil {
+		t.Fatalf("decoding subscription: %v", err)
+	}
+
+	want := Subscription{
+		ID:               "S18577447",
+		Status:           StatusActive,
+		Plan:             "Pro Annual",
+		CreatedAt:        CBTime{time.Date(2023, 3, 14, 9, 26, 53, 0, time.UTC)},
+		CustomerID:       "C4711",
+		ProductID:        "P2024",
+		Quantity:         5,
+		NextBillingDate:  CBTime{time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)},
+		CurrentPeriodEnd: CBTime{time.Date(2025, 3, 13, 23, 59, 59, 0, time.UTC)},
+		Amount:           Money(49995),
+		Currency:         "EUR",
+		BillingCycle:     BillingYearly,
+		PurchaseID:       "P58123901",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subscription =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestSubscriptionIDsAsStringsOrNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"strings", `{"id":"18577447","customerId":"4711","productId":"2024","purchaseId":"58123901123456789","quantity":2}`},
+		{"numbers", `{"id":18577447,"customerId":4711,"productId":2024,"purchaseId":58123901123456789,"quantity":2}`},
+	}
+	want := Subscription{ID: "18577447", CustomerID: "4711", ProductID: "2024", PurchaseID: "58123901123456789", Quantity: 2}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Subscription
+			if err := json.Unmarshal([]byte(tt.body), &got); err != nil {
+				t.Fatalf("decoding %s: %v", tt.body, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("subscription = %+v, want %+v", got, want)
+			}
+
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Contains(encoded, []byte(`"id":"18577447"`)) {
+				t.Errorf("encoded = %s, want ids written as strings", encoded)
+			}
+		})
+	}
+
+	var got Subscription
+	if err := json.Unmarshal([]byte(`{"id":true}`), &got); err == nil {
+		t.Error("decoding a boolean id succeeded, want an error")
+	}
+}
+
+func TestExpandedSubscriptionWithNumericIDs(t *testing.T) {
+	body := `{"id":18577447,"status":"active","customer":{"id":"C1"},"product":{"id":"P1"}}`
+
+	var got ExpandedSubscription
+	if err := json.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatalf("decoding expanded subscription: %v", err)
+	}
+	if got.ID != "18577447" || got.Status != StatusActive {
+		t.Errorf("subscription = %+v, want 18577447 active", got.Subscription)
+	}
+	if got.Customer == nil || got.Product == nil {
+		t.Errorf("customer = %v, product = %v, want both decoded", got.Customer, got.Product)
+	}
+}
+
+func TestStrictDecodingChecksSubscriptionFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		v       interface{}
+		wantErr bool
+	}{
+		{"numeric ids", `{"id":18577447,"customerId":4711}`, &Subscription{}, false},
+		{"unknown field", `{"id":"S1","renamedField":1}`, &Subscription{}, true},
+		{"unknown field in a list", `[{"id":"S1"},{"id":"S2","renamedField":1}]`, &[]Subscription{}, true},
+		{"expanded", `{"id":1,"customer":{"id":"C1"},"product":{"id":"P1"}}`, &ExpandedSubscription{}, false},
+		{"unknown field in expanded customer", `{"id":1,"customer":{"id":"C1","nickname":"x"}}`, &ExpandedSubscription{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := unmarshalJSON([]byte(tt.body), tt.v, true)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unmarshalJSON(%s) error = %v, want error %v", tt.body, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is a monetary amount in hundredths of the major currency unit, so sums
+// and comparisons are exact. The scale is the same for every currency: JPY 500
+// is Money(50000) and amounts with more than two decimals, such as KWD 1.234,
+// cannot be represented and are rejected by ParseMoney. The currency is carried
+// separately, as in the API.
+type Money int64
+
+// maxMoneyExponent bounds the exponent accepted by ParseMoney
+const maxMoneyExponent = 20
+
+// MoneyFromFloat converts a float amount such as 19.99 to Money, rounding to the nearest minor unit
+func MoneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// ParseMoney parses a decimal amount such as "19.99" or "1.999e1" without going
+// through float64. Amounts with non-zero digits beyond the second decimal place
+// are rejected rather than rounded, so no precision is lost silently.
+func ParseMoney(s string) (Money, error) {
+	input := strings.TrimSpace(s)
+	if input == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	number := input
+	negative := false
+	switch number[0] {
+	case '-':
+		negative = true
+		number = number[1:]
+	case '+':
+		number = number[1:]
+	}
+
+	exponent := 0
+	if i := strings.IndexAny(number, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(number[i+1:])
+		if err != nil || exp < -maxMoneyExponent || exp > maxMoneyExponent {
+			return 0, fmt.Errorf("invalid amount %q", input)
+		}
+		number, exponent = number[:i], exp
+	}
+
+	whole, frac, _ := strings.Cut(number, ".")
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("invalid amount %q", input)
+	}
+
+	// Move the decimal point by the exponent
+	digits := whole + frac
+	point := len(whole) + exponent
+	if point < 0 {
+		digits = strings.Repeat("0", -point) + digits
+		point = 0
+	}
+	if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	whole, frac = digits[:point], strings.TrimRight(digits[point:], "0")
+
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("amount %q has more than two decimal places", input)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	cents, _ := strconv.ParseInt((frac + "00")[:2], 10, 64)
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > (math.MaxInt64-cents)/100 {
+		return 0, fmt.Errorf("amount %q is out of range", input)
+	}
+
+	amount := Money(units*100 + cents)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// isDigits reports whether s consists of ASCII digits only; empty counts
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Float64 returns the amount in major units, for display and reporting only
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String formats the amount with two decimal places, e.g. "19.99"
+func (m Money) String() string {
+	sign := ""
+	minor := int64(m)
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
+
+// UnmarshalJSON accepts the amount as a JSON number or a numeric string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = 0
+		return nil
+	}
+
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if text == "" {
+			*m = 0
+			return nil
+		}
+	}
+
+	amount, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimal places
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
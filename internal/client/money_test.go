@@ -0,0 +1,82 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Money
+	}{
+		{"19.99", 1999},
+		{"19.9", 1990},
+		{"19", 1900},
+		{".5", 50},
+		{"-0.01", -1},
+		{"+7.10", 710},
+		{"19.990", 1999},
+		{"1e2", 10000},
+		{"1.999E1", 1999},
+		{"1999e-2", 1999},
+		{"-2.5e-1", -25},
+		{"0.100000e1", 100},
+		{"92233720368547758", 9223372036854775800},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.input)
+		if err != nil {
+			t.Errorf("ParseMoney(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseMoneyRejectsLossyOrInvalidInput(t *testing.T) {
+	for _, input := range []string{
+		"1.234",  // KWD, three decimals
+		"19.999", // would have to round
+		"1e-3",
+		"0.10000000000000000001e1",
+		"",
+		".",
+		"abc",
+		"1.2.3",
+		"1e",
+		"1e999",
+		"99999999999999999999",
+	} {
+		if got, err := ParseMoney(input); err == nil {
+			t.Errorf("ParseMoney(%q) = %d, want error", input, got)
+		}
+	}
+}
+
+func TestMoneyJSONRoundTripIsExact(t *testing.T) {
+	// 0.1 + 0.2 is not 0.3 in float64; in Money it is
+	var a, b Money
+	if err := json.Unmarshal([]byte(`0.1`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`"0.2"`), &b); err != nil {
+		t.Fatal(err)
+	}
+	if sum := a + b; sum != 30 || sum.String() != "0.30" {
+		t.Errorf("0.1 + 0.2 = %s, want 0.30", sum)
+	}
+
+	data, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{Amount: 123456789})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"amount":1234567.89}` {
+		t.Errorf("Marshal = %s", data)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ListNotifications returns the notifications created since the given time,
+// following pagination, e.g. to catch up on webhooks missed while the receiving
+// endpoint was down. A zero since lists all notifications the API keeps.
+// A notification that cannot be decoded is returned as a *ParseError holding
+// the start of that item.
+func (c *BaseClient) ListNotifications(ctx context.Context, since time.Time, opts ...CallOption) ([]Notification, error) {
+	c.log(ctx).Info("Listing notifications", "since", since)
+
+	queryParams := query{}
+	if !since.IsZero() {
+		queryParams.set("since", since.UTC().Format(time.RFC3339))
+	}
+
+	raws, err := paginate[json.RawMessage](ctx, c, "/notification/getnotifications", queryParams, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to list notifications", err, "since", since)
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	notifications := make([]Notification, 0, len(raws))
+	for _, raw := range raws {
+		var notification Notification
+		if err := unmarshalJSON(raw, &notification, c.config.StrictDecoding); err != nil {
+			c.log(ctx).Error("Failed to parse notification", err,
+				"response_body", c.logBody(raw))
+			return nil, fmt.Errorf("failed to parse notification: %w", &ParseError{
+				Endpoint:   "/notification/getnotifications",
+				StatusCode: http.StatusOK,
+				Snippet:    truncate(redactJSON(raw), parseErrorSnippetBytes),
+				Err:        err,
+			})
+		}
+		notification.Raw = raw
+		notifications = append(notifications, notification)
+	}
+
+	c.log(ctx).Info("Successfully listed notifications",
+		"since", since,
+		"notifications_count", len(notifications))
+
+	return notifications, nil
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListNotificationsParseError(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK,
+		`[{"notificationId":"N1","eventType":"SubscriptionCanceled"},{"notificationId":42,"eventType":"SubscriptionRenewed"}]`))
+
+	_, err := c.ListNotifications(context.Background(), time.Time{})
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("error = %v, want a *ParseError", err)
+	}
+	if parseErr.Endpoint != "/notification/getnotifications" {
+		t.Errorf("Endpoint = %q, want /notification/getnotifications", parseErr.Endpoint)
+	}
+	if !strings.Contains(parseErr.Snippet, "SubscriptionRenewed") || strings.Contains(parseErr.Snippet, "N1") {
+		t.Errorf("Snippet = %q, want the undecodable item only", parseErr.Snippet)
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"crypto/x509"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Option configures a BaseClient in NewBaseClient
+type Option func(*BaseClient)
+
+// WithLogger makes the client log through the given logger instead of creating its own.
+// A *StdLogger can be shared by several clients: each client holds a reference that
+// its Close releases, and the log file is closed after the last one, including the
+// caller's own Close on the logger.
+func WithLogger(logger Logger) Option {
+	return func(c *BaseClient) {
+		c.logger = logger
+	}
+}
+
+// WithWriter makes the built-in logger write to w instead of stdout or the log file.
+// It has no effect together with WithLogger.
+func WithWriter(w io.Writer) Option {
+	return func(c *BaseClient) {
+		c.logWriter = w
+	}
+}
+
+// WithHTTPClient makes the client send requests through the given HTTP client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *BaseClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries overrides MaxRetries and RetryBaseDelay from the config
+func WithRetries(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *BaseClient) {
+		c.config.MaxRetries = maxRetries
+		c.config.RetryBaseDelay = baseDelay
+	}
+}
+
+// WithMaxResponseSize overrides MaxResponseBytes from the config
+func WithMaxResponseSize(maxBytes int64) Option {
+	return func(c *BaseClient) {
+		c.config.MaxResponseBytes = maxBytes
+	}
+}
+
+// WithBaseURL overrides BaseURL from the config
+func WithBaseURL(baseURL string) Option {
+	return func(c *BaseClient) {
+		c.config.BaseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *BaseClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRootCAs verifies the API server against the given certificate pool instead
+// of the system roots. It has no effect together with WithHTTPClient.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *BaseClient) {
+		c.rootCAs = pool
+	}
+}
@@ -0,0 +1,322 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultPageSize = 100
+
+// Page is one page of a paginated list endpoint
+type Page[T any] struct {
+	Items      []T  `json:"items"`
+	TotalCount int  `json:"totalCount"`
+	HasMore    bool `json:"hasMore"`
+}
+
+type SubscriptionPage = Page[Subscription]
+
+type ProductPage = Page[Product]
+
+// PageOptions selects a page of a list endpoint. Page is 1-based; zero values
+// request the first page with the default page size.
+type PageOptions struct {
+	Page     int
+	PageSize int
+}
+
+func (o PageOptions) normalize() PageOptions {
+	if o.Page < 1 {
+		o.Page = 1
+	}
+	if o.PageSize < 1 {
+		o.PageSize = defaultPageSize
+	}
+	return o
+}
+
+func (o PageOptions) addTo(queryParams query) {
+	queryParams.
+		set("page", o.Page).
+		set("pageSize", o.PageSize)
+}
+
+// SubscriptionFilter narrows ListSubscriptions. Zero fields are not filtered on;
+// CreatedFrom and CreatedTo are inclusive dates.
+type SubscriptionFilter struct {
+	Status       SubscriptionStatus
+	ProductID    string
+	BillingCycle BillingCycle
+	CreatedFrom  time.Time
+	CreatedTo    time.Time
+	PageOptions
+}
+
+func (f SubscriptionFilter) addTo(queryParams query) {
+	queryParams.
+		set("status", string(f.Status)).
+		set("productId", f.ProductID).
+		set("billingCycle", string(f.BillingCycle)).
+		set("createdFrom", f.CreatedFrom).
+		set("createdTo", f.CreatedTo)
+	f.PageOptions.addTo(queryParams)
+}
+
+// ListOptions selects a window of an offset-based list endpoint. A zero Limit
+// uses the default page size. Name, when set, filters results by name.
+type ListOptions struct {
+	Offset int
+	Limit  int
+	Name   string
+}
+
+func (o ListOptions) normalize() ListOptions {
+	if o.Offset < 0 {
+		o.Offset = 0
+	}
+	if o.Limit < 1 {
+		o.Limit = defaultPageSize
+	}
+	return o
+}
+
+func (o ListOptions) addTo(queryParams query) {
+	queryParams.
+		set("offset", o.Offset).
+		set("limit", o.Limit).
+		set("name", o.Name)
+}
+
+// decodePage parses a paginated response. Both the {items, totalCount, hasMore}
+// envelope and a bare JSON array are accepted; for a bare array HasMore is
+// derived from whether the page came back full. offset is the number of items
+// before this page and limit the requested page size. strict rejects unknown
+// fields as in decodeJSON. Failures are returned as a *ParseError.
+func decodePage[T any](resp *Response, offset, limit int, strict bool) (*Page[T], error) {
+	page, err := decodePageBody[T](resp.Body, offset, limit, strict)
+	if err != nil {
+		return nil, resp.parseError(err)
+	}
+	return page, nil
+}
+
+func decodePageBody[T any](body []byte, offset, limit int, strict bool) (*Page[T], error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return &Page[T]{}, nil
+	}
+
+	if body[0] == '[' {
+		var items []T
+		if err := unmarshalJSON(body, &items, strict); err != nil {
+			return nil, err
+		}
+		return &Page[T]{
+			Items:      items,
+			TotalCount: offset + len(items),
+			HasMore:    len(items) == limit,
+		}, nil
+	}
+
+	var envelope struct {
+		Items      []T   `json:"items"`
+		TotalCount int   `json:"totalCount"`
+		HasMore    *bool `json:"hasMore"`
+	}
+	if err := unmarshalJSON(body, &envelope, strict); err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{
+		Items:      envelope.Items,
+		TotalCount: envelope.TotalCount,
+	}
+	if envelope.HasMore != nil {
+		page.HasMore = *envelope.HasMore
+	} else {
+		page.HasMore = offset+len(envelope.Items) < envelope.TotalCount
+	}
+	return page, nil
+}
+
+// maxCursorPages stops paginate from following a cursor that never ends
+const maxCursorPages = 10000
+
+// cursorPage is the part of a cursor-paginated body that links to the next page
+type cursorPage struct {
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// paginate fetches a list endpoint and follows its cursor until the last page,
+// returning all items. See paginateFunc for how the next page is found.
+func paginate[T any](ctx context.Context, c *BaseClient, path string, params query, opts ...CallOption) ([]T, error) {
+	items := []T{}
+	err := paginateFunc(ctx, c, path, params, func(page []T) error {
+		items = append(items, page...)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// paginateFunc fetches a list endpoint and calls fn with the items of each page
+// until the last page or the first error returned by fn. The next page is found
+// from a Link header with rel="next" or, failing that, a nextPageToken field in
+// the body, which is sent back as the pageToken parameter. Without a cursor, a
+// request carrying a page parameter moves on to the next page number while the
+// response reports more items, as in decodePage. Any other response is the last
+// page. Each page may be a bare array or an {items} envelope.
+func paginateFunc[T any](ctx context.Context, c *BaseClient, path string, params query, fn func([]T) error, opts ...CallOption) error {
+	seen := map[string]bool{}
+
+	for pages := 0; pages < maxCursorPages; pages++ {
+		resp, err := c.do(ctx, Request{Method: http.MethodGet, Path: path, QueryParams: params}, opts...)
+		if err != nil {
+			return err
+		}
+
+		pageItems, err := decodeList[T](resp, c.config.StrictDecoding)
+		if err != nil {
+			c.log(ctx).Error("Failed to parse page", err,
+				"path", path,
+				"response_body", c.logBody(resp.Body))
+			return err
+		}
+		if err := fn(pageItems); err != nil {
+			return err
+		}
+
+		nextPath, nextParams, ok := c.nextPage(resp, path, params)
+		if !ok {
+			return nil
+		}
+		cursor := nextPath + "?" + toValues(nextParams).Encode()
+		if seen[cursor] {
+			return fmt.Errorf("pagination cursor for %s repeats, giving up", path)
+		}
+		seen[cursor] = true
+		path, params = nextPath, nextParams
+	}
+	return fmt.Errorf("pagination of %s exceeded %d pages", path, maxCursorPages)
+}
+
+// nextPage works out the request for the page after resp, if there is one
+func (c *BaseClient) nextPage(resp *Response, path string, params query) (string, query, bool) {
+	if link := nextLink(resp.Headers); link != "" {
+		if nextPath, nextParams, ok := c.resolveLink(link); ok {
+			return nextPath, nextParams, true
+		}
+	}
+
+	body := bytes.TrimSpace(resp.Body)
+	var cursor cursorPage
+	if len(body) > 0 && body[0] == '{' && json.Unmarshal(body, &cursor) == nil && cursor.NextPageToken != "" {
+		nextParams := copyQuery(params)
+		nextParams.set("pageToken", cursor.NextPageToken)
+		return path, nextParams, true
+	}
+
+	return nextPageNumber(body, path, params)
+}
+
+// nextPageNumber works out the next page of an endpoint paged by number, from
+// the page and pageSize parameters of the request and the hasMore or totalCount
+// of the response
+func nextPageNumber(body []byte, path string, params query) (string, query, bool) {
+	number, err := strconv.Atoi(params["page"])
+	if err != nil || number < 1 {
+		return "", nil, false
+	}
+	opts := PageOptions{Page: number}
+	opts.PageSize, _ = strconv.Atoi(params["pageSize"])
+	opts = opts.normalize()
+
+	page, err := decodePageBody[json.RawMessage](body, (opts.Page-1)*opts.PageSize, opts.PageSize, false)
+	if err != nil || !page.HasMore || len(page.Items) == 0 {
+		return "", nil, false
+	}
+
+	nextParams := copyQuery(params)
+	nextParams.set("page", opts.Page+1)
+	return path, nextParams, true
+}
+
+func copyQuery(params query) query {
+	copied := make(query, len(params))
+	for key, value := range params {
+		copied[key] = value
+	}
+	return copied
+}
+
+// resolveLink turns a next link, absolute or relative to the base URL, into a
+// path and query. Links to other hosts are not followed.
+func (c *BaseClient) resolveLink(link string) (string, query, bool) {
+	base, err := url.Parse(c.baseURL + "/")
+	if err != nil {
+		return "", nil, false
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return "", nil, false
+	}
+	next := base.ResolveReference(ref)
+	if next.Host != base.Host {
+		return "", nil, false
+	}
+
+	nextPath := "/" + strings.TrimPrefix(next.Path, base.Path)
+	nextParams := query{}
+	for key, values := range next.Query() {
+		if len(values) > 0 {
+			nextParams[key] = values[0]
+		}
+	}
+	return nextPath, nextParams, true
+}
+
+// nextLink returns the target of the rel="next" entry of the Link headers
+func nextLink(headers http.Header) string {
+	for _, header := range headers.Values("Link") {
+		for _, entry := range strings.Split(header, ",") {
+			target, attrs, ok := strings.Cut(entry, ";")
+			if !ok {
+				continue
+			}
+			for _, attr := range strings.Split(attrs, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(attr), "=")
+				if strings.EqualFold(key, "rel") && hasToken(strings.Trim(value, `"`), "next") {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// hasToken reports whether the space-separated list contains token
+func hasToken(list, token string) bool {
+	for _, field := range strings.Fields(list) {
+		if strings.EqualFold(field, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func toValues(params query) url.Values {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	return values
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// linkPager serves three pages of subscriptions linked with Link headers
+func linkPager(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("customerId"); got != "C1" {
+			t.Errorf("customerId = %q, want C1", got)
+		}
+		switch cursor := r.URL.Query().Get("cursor"); cursor {
+		case "":
+			w.Header().Set("Link", `</subscription/getsubscriptionsforcustomer?customerId=C1&cursor=2>; rel="next"`)
+			w.Write([]byte(`[{"id":"S1"},{"id":"S2"}]`))
+		case "2":
+			w.Header().Add("Link", `</subscription/getsubscriptionsforcustomer?customerId=C1>; rel="first"`)
+			w.Header().Add("Link", `</subscription/getsubscriptionsforcustomer?customerId=C1&cursor=3>; rel="next"`)
+			w.Write([]byte(`{"items":[{"id":"S3"}]}`))
+		case "3":
+			w.Write([]byte(`[{"id":"S4"}]`))
+		default:
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	})
+}
+
+// tokenPager serves three pages of subscriptions linked with nextPageToken
+func tokenPager(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("customerId"); got != "C1" {
+			t.Errorf("customerId = %q, want C1", got)
+		}
+		switch token := r.URL.Query().Get("pageToken"); token {
+		case "":
+			w.Write([]byte(`{"items":[{"id":"S1"},{"id":"S2"}],"nextPageToken":"t2"}`))
+		case "t2":
+			w.Write([]byte(`{"items":[{"id":"S3"}],"nextPageToken":"t3"}`))
+		case "t3":
+			w.Write([]byte(`{"items":[{"id":"S4"}],"nextPageToken":""}`))
+		default:
+			t.Errorf("unexpected pageToken %q", token)
+		}
+	})
+}
+
+func subscriptionIDs(subscriptions []Subscription) []string {
+	ids := make([]string, len(subscriptions))
+	for i, s := range subscriptions {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(t *testing.T) http.Handler
+	}{
+		{"Link header", linkPager},
+		{"nextPageToken", tokenPager},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.handler(t))
+
+			subscriptions, err := paginate[Subscription](context.Background(), c, "/subscription/getsubscriptionsforcustomer", query{"customerId": "C1"})
+			if err != nil {
+				t.Fatalf("paginate error: %v", err)
+			}
+			want := []string{"S1", "S2", "S3", "S4"}
+			if got := subscriptionIDs(subscriptions); !reflect.DeepEqual(got, want) {
+				t.Errorf("subscriptions = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIterateSubscriptionsForCustomerFollowsCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(t *testing.T) http.Handler
+	}{
+		{"Link header", linkPager},
+		{"nextPageToken", tokenPager},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.handler(t))
+
+			var got []string
+			err := c.IterateSubscriptionsForCustomer(context.Background(), "C1", 2, func(s Subscription) error {
+				got = append(got, s.ID)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("IterateSubscriptionsForCustomer error: %v", err)
+			}
+			want := []string{"S1", "S2", "S3", "S4"}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("subscriptions = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIterateSubscriptionsForCustomerStopsOnError(t *testing.T) {
+	var requests int
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		tokenPager(t).ServeHTTP(w, r)
+	}))
+
+	stop := errors.New("stop")
+	err := c.IterateSubscriptionsForCustomer(context.Background(), "C1", 0, func(s Subscription) error {
+		if s.ID == "S2" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("error = %v, want the error returned by fn", err)
+	}
+	if requests != 1 {
+		t.Errorf("fetched %d pages, want 1", requests)
+	}
+}
+
+func TestPaginateRepeatingCursor(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[{"id":"S1"}],"nextPageToken":"same"}`)
+	}))
+
+	_, err := paginate[Subscription](context.Background(), c, "/subscription/getsubscriptionsforcustomer", query{})
+	if err == nil {
+		t.Fatal("paginate succeeded, want an error for the repeating cursor")
+	}
+}
+
+func TestIterateSubscriptionsForCustomerByPageNumber(t *testing.T) {
+	pages := map[string]map[string]string{
+		"hasMore": {
+			"1": `{"items":[{"id":"S1"},{"id":"S2"}],"hasMore":true}`,
+			"2": `{"items":[{"id":"S3"},{"id":"S4"}],"hasMore":true}`,
+			"3": `{"items":[{"id":"S5"}],"hasMore":false}`,
+		},
+		"totalCount": {
+			"1": `{"items":[{"id":"S1"},{"id":"S2"}],"totalCount":5}`,
+			"2": `{"items":[{"id":"S3"},{"id":"S4"}],"totalCount":5}`,
+			"3": `{"items":[{"id":"S5"}],"totalCount":5}`,
+		},
+		"bare arrays": {
+			"1": `[{"id":"S1"},{"id":"S2"}]`,
+			"2": `[{"id":"S3"},{"id":"S4"}]`,
+			"3": `[{"id":"S5"}]`,
+		},
+	}
+
+	for name, bodies := range pages {
+		t.Run(name, func(t *testing.T) {
+			var requested []string
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page := r.URL.Query().Get("page")
+				if got := r.URL.Query().Get("pageSize"); got != "2" {
+					t.Errorf("pageSize = %q, want 2", got)
+				}
+				requested = append(requested, page)
+				w.Write([]byte(bodies[page]))
+			}))
+
+			var got []string
+			err := c.IterateSubscriptionsForCustomer(context.Background(), "C1", 2, func(s Subscription) error {
+				got = append(got, s.ID)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("IterateSubscriptionsForCustomer error: %v", err)
+			}
+			if want := []string{"S1", "S2", "S3", "S4", "S5"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("subscriptions = %v, want %v", got, want)
+			}
+			if want := []string{"1", "2", "3"}; !reflect.DeepEqual(requested, want) {
+				t.Errorf("requested pages %v, want %v", requested, want)
+			}
+		})
+	}
+}
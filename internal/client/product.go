@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+func (c *BaseClient) GetProduct(ctx context.Context, productID string, opts ...CallOption) (*Product, error) {
+	if productID == "" {
+		return nil, fmt.Errorf("product ID is required")
+	}
+
+	c.log(ctx).Info("Getting product", "product_id", productID)
+
+	queryParams := query{}.set("productId", productID)
+
+	resp, err := c.sendRequest(ctx, "GET", "/product/getproduct", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get product", err,
+			"product_id", productID)
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	var product Product
+	if err := c.decodeJSON(resp, &product); err != nil {
+		c.log(ctx).Error("Failed to parse product response", err,
+			"product_id", productID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse product: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved product",
+		"product_id", product.ID,
+		"name", product.Name)
+
+	return &product, nil
+}
+
+// ListProducts returns one window of the product catalog along with the total
+// number of matching products.
+func (c *BaseClient) ListProducts(ctx context.Context, opts ListOptions, callOpts ...CallOption) (*ProductPage, error) {
+	opts = opts.normalize()
+
+	c.log(ctx).Info("Listing products",
+		"offset", opts.Offset,
+		"limit", opts.Limit,
+		"name", opts.Name)
+
+	queryParams := query{}
+	opts.addTo(queryParams)
+
+	resp, err := c.sendRequest(ctx, "GET", "/product/getproducts", queryParams, nil, callOpts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to list products", err,
+			"offset", opts.Offset)
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	page, err := decodePage[Product](resp, opts.Offset, opts.Limit, c.config.StrictDecoding)
+	if err != nil {
+		c.log(ctx).Error("Failed to parse products response", err,
+			"offset", opts.Offset,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse products: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully listed products",
+		"products_count", len(page.Items),
+		"total_count", page.TotalCount,
+		"has_more", page.HasMore)
+
+	return page, nil
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+func (c *BaseClient) GetPurchase(ctx context.Context, purchaseID string, opts ...CallOption) (*Purchase, error) {
+	if purchaseID == "" {
+		return nil, fmt.Errorf("purchase ID is required")
+	}
+
+	c.log(ctx).Info("Getting purchase", "purchase_id", purchaseID)
+
+	queryParams := query{}.set("purchaseId", purchaseID)
+
+	resp, err := c.sendRequest(ctx, "GET", "/purchase/getpurchase", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get purchase", err,
+			"purchase_id", purchaseID)
+		return nil, fmt.Errorf("failed to get purchase: %w", err)
+	}
+
+	var purchase Purchase
+	if err := c.decodeJSON(resp, &purchase); err != nil {
+		c.log(ctx).Error("Failed to parse purchase response", err,
+			"purchase_id", purchaseID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse purchase: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved purchase",
+		"purchase_id", purchase.ID,
+		"status", purchase.Status,
+		"items_count", len(purchase.Items))
+
+	return &purchase, nil
+}
+
+// GetPurchaseWithSubscriptions fetches a purchase and its subscriptions with
+// two concurrent requests. Whatever could be fetched is returned: when one of
+// the requests fails, the other's result comes back together with the error,
+// and failures of both are combined with errors.Join. Call options apply to
+// both requests, except WithResponseHeaders, which receives the headers of the
+// purchase request only.
+func (c *BaseClient) GetPurchaseWithSubscriptions(ctx context.Context, purchaseID string, opts ...CallOption) (*Purchase, []Subscription, error) {
+	if purchaseID == "" {
+		return nil, nil, fmt.Errorf("purchase ID is required")
+	}
+
+	var (
+		purchase         *Purchase
+		subscriptions    []Subscription
+		purchaseErr      error
+		subscriptionsErr error
+		wg               sync.WaitGroup
+	)
+	// Only one of the concurrent requests may write back to the caller
+	subscriptionOpts := append(append([]CallOption(nil), opts...), withoutResponseHeaders())
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		purchase, purchaseErr = c.GetPurchase(ctx, purchaseID, opts...)
+	}()
+	go func() {
+		defer wg.Done()
+		subscriptions, subscriptionsErr = c.GetSubscriptionsByPurchase(ctx, purchaseID, subscriptionOpts...)
+	}()
+	wg.Wait()
+
+	return purchase, subscriptions, errors.Join(purchaseErr, subscriptionsErr)
+}
+
+// RefundPurchase refunds a purchase. A zero amount refunds the full purchase,
+// otherwise only the given amount is refunded. If Cleverbridge refuses the refund,
+// e.g. because the purchase is too old or already fully refunded, the returned
+// error wraps an *APIError.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) RefundPurchase(ctx context.Context, purchaseID string, amount Money, reason string, opts ...CallOption) (*Refund, error) {
+	if purchaseID == "" {
+		return nil, fmt.Errorf("purchase ID is required")
+	}
+	if amount < 0 {
+		return nil, fmt.Errorf("refund amount must not be negative, got %s", amount)
+	}
+
+	c.log(ctx).Info("Refunding purchase",
+		"purchase_id", purchaseID,
+		"amount", amount,
+		"reason", reason)
+
+	body := refundPurchaseRequest{
+		PurchaseID: purchaseID,
+		Reason:     reason,
+	}
+	if amount > 0 {
+		body.Amount = &amount
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/purchase/refundpurchase", nil, body, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to refund purchase", err,
+			"purchase_id", purchaseID)
+		return nil, fmt.Errorf("failed to refund purchase: %w", err)
+	}
+
+	var refund Refund
+	if err := c.decodeJSON(resp, &refund); err != nil {
+		c.log(ctx).Error("Failed to parse refund response", err,
+			"purchase_id", purchaseID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse refund: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully refunded purchase",
+		"purchase_id", purchaseID,
+		"refund_id", refund.ID,
+		"amount", refund.Amount,
+		"status", refund.Status)
+
+	return &refund, nil
+}
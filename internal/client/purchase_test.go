@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetPurchaseWithSubscriptionsResponseHeaders(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Endpoint", r.URL.Path)
+		switch r.URL.Path {
+		case "/purchase/getpurchase":
+			w.Write([]byte(`{"id":"P1"}`))
+		default:
+			w.Write([]byte(`[{"id":"S1"},{"id":"S2"}]`))
+		}
+	}))
+
+	var headers http.Header
+	purchase, subscriptions, err := c.GetPurchaseWithSubscriptions(context.Background(), "P1", WithResponseHeaders(&headers))
+	if err != nil {
+		t.Fatalf("GetPurchaseWithSubscriptions error: %v", err)
+	}
+	if purchase == nil || len(subscriptions) != 2 {
+		t.Errorf("purchase = %+v, subscriptions = %d, want P1 and 2", purchase, len(subscriptions))
+	}
+	if got := headers.Get("X-Endpoint"); got != "/purchase/getpurchase" {
+		t.Errorf("response headers from %q, want those of the purchase request", got)
+	}
+}
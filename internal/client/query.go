@@ -0,0 +1,35 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// query builds the query parameters of a request. Empty strings and zero times
+// are left out so optional parameters are not sent blank.
+type query map[string]string
+
+// set adds key with value formatted for the API: bools as true/false, times as
+// UTC dates and Money as a decimal amount
+func (q query) set(key string, value interface{}) query {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			q[key] = v
+		}
+	case bool:
+		q[key] = strconv.FormatBool(v)
+	case int:
+		q[key] = strconv.Itoa(v)
+	case time.Time:
+		if !v.IsZero() {
+			q[key] = v.UTC().Format(dateFormat)
+		}
+	case fmt.Stringer:
+		q[key] = v.String()
+	default:
+		q[key] = fmt.Sprint(v)
+	}
+	return q
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuerySet(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  map[string]string
+	}{
+		{"string", "S1", map[string]string{"key": "S1"}},
+		{"empty string is omitted", "", map[string]string{}},
+		{"int", 25, map[string]string{"key": "25"}},
+		{"zero int is kept", 0, map[string]string{"key": "0"}},
+		{"time as UTC date", time.Date(2024, 3, 1, 1, 0, 0, 0, berlin), map[string]string{"key": "2024-02-29"}},
+		{"zero time is omitted", time.Time{}, map[string]string{}},
+		{"Stringer", StatusActive, map[string]string{"key": "active"}},
+		{"Money", Money(1999), map[string]string{"key": "19.99"}},
+		{"other types", 1.5, map[string]string{"key": "1.5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := query{}.set("key", tt.value)
+			if !reflect.DeepEqual(map[string]string(got), tt.want) {
+				t.Errorf("set(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuerySetChains(t *testing.T) {
+	got := query{}.
+		set("subscriptionId", "S1").
+		set("reason", "").
+		set("pageSize", 50)
+
+	want := map[string]string{"subscriptionId": "S1", "pageSize": "50"}
+	if !reflect.DeepEqual(map[string]string(got), want) {
+		t.Errorf("query = %v, want %v", got, want)
+	}
+}
+
+func TestQuerySetBool(t *testing.T) {
+	for _, value := range []bool{true, false} {
+		got := query{}.set("isCurrent", value)
+		want := map[string]string{"isCurrent": map[bool]string{true: "true", false: "false"}[value]}
+		if !reflect.DeepEqual(map[string]string(got), want) {
+			t.Errorf("set(%v) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestGetSubscriptionSendsIsCurrent(t *testing.T) {
+	tests := []struct {
+		view SubscriptionView
+		want string
+	}{
+		{SubscriptionViewCurrent, "true"},
+		{SubscriptionViewOriginal, "false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.view), func(t *testing.T) {
+			var got string
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Query().Get("isCurrent")
+				w.Write([]byte(`{"id":"S1"}`))
+			}))
+
+			if _, err := c.GetSubscription(context.Background(), "S1", tt.view); err != nil {
+				t.Fatalf("GetSubscription error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isCurrent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitStatus is the API's rate limit budget as reported by the
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers of
+// the most recent response that carried them
+type RateLimitStatus struct {
+	Limit     int64
+	Remaining int64
+	// Reset is when the budget is replenished, zero when the API did not say
+	Reset time.Time
+	// ObservedAt is when the response was received, zero if no response carried
+	// rate limit headers yet
+	ObservedAt time.Time
+}
+
+// rateLimitTracker keeps the latest RateLimitStatus for LastRateLimit
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	status RateLimitStatus
+}
+
+// LastRateLimit returns the rate limit status of the most recent response that
+// carried rate limit headers, e.g. to slow down before the API starts answering
+// 429. It is safe for concurrent use.
+func (c *BaseClient) LastRateLimit() RateLimitStatus {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	return c.rateLimit.status
+}
+
+func (t *rateLimitTracker) update(status RateLimitStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status = status
+}
+
+// parseRateLimit reads the X-RateLimit-* headers. ok is false when the response
+// has none of them. X-RateLimit-Reset may be a Unix timestamp or a number of
+// seconds from now.
+func parseRateLimit(headers http.Header, now time.Time) (status RateLimitStatus, ok bool) {
+	limit, hasLimit := headerInt(headers, "X-RateLimit-Limit")
+	remaining, hasRemaining := headerInt(headers, "X-RateLimit-Remaining")
+	reset, hasReset := headerInt(headers, "X-RateLimit-Reset")
+	if !hasLimit && !hasRemaining && !hasReset {
+		return RateLimitStatus{}, false
+	}
+
+	status = RateLimitStatus{Limit: limit, Remaining: remaining, ObservedAt: now}
+	if hasReset {
+		// Values this large can only be timestamps, not a delay in seconds
+		if reset > 1_000_000_000 {
+			status.Reset = time.Unix(reset, 0)
+		} else {
+			status.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return status, true
+}
+
+func headerInt(headers http.Header, name string) (int64, bool) {
+	value := strings.TrimSpace(headers.Get(name))
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fixedClock is a Clock stopped at a given time
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func (c fixedClock) Sleep(ctx context.Context, d time.Duration) error { return ctx.Err() }
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    RateLimitStatus
+		wantOK  bool
+	}{
+		{"reset in seconds", map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "42", "X-RateLimit-Reset": "30"},
+			RateLimitStatus{Limit: 100, Remaining: 42, Reset: now.Add(30 * time.Second), ObservedAt: now}, true},
+		{"reset as Unix timestamp", map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1714565000"},
+			RateLimitStatus{Limit: 100, Remaining: 0, Reset: time.Unix(1714565000, 0), ObservedAt: now}, true},
+		{"without reset", map[string]string{"X-RateLimit-Remaining": "7"},
+			RateLimitStatus{Remaining: 7, ObservedAt: now}, true},
+		{"malformed values are ignored", map[string]string{"X-RateLimit-Limit": "lots", "X-RateLimit-Remaining": "-1"},
+			RateLimitStatus{}, false},
+		{"no headers", map[string]string{}, RateLimitStatus{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for key, value := range tt.headers {
+				headers.Set(key, value)
+			}
+			got, ok := parseRateLimit(headers, now)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining ||
+				!got.Reset.Equal(tt.want.Reset) || !got.ObservedAt.Equal(tt.want.ObservedAt) {
+				t.Errorf("status = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastRateLimit(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	withHeaders := true
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if withHeaders {
+			w.Header().Set("X-RateLimit-Limit", "600")
+			w.Header().Set("X-RateLimit-Remaining", "599")
+			w.Header().Set("X-RateLimit-Reset", "60")
+		}
+		w.Write([]byte(`{"id":"S1"}`))
+	}), WithClock(fixedClock{now}))
+
+	if got := c.LastRateLimit(); !got.ObservedAt.IsZero() {
+		t.Errorf("LastRateLimit before any request = %+v, want the zero value", got)
+	}
+
+	ctx := context.Background()
+	if _, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent); err != nil {
+		t.Fatal(err)
+	}
+	want := RateLimitStatus{Limit: 600, Remaining: 599, Reset: now.Add(time.Minute), ObservedAt: now}
+	if got := c.LastRateLimit(); got != want {
+		t.Errorf("LastRateLimit = %+v, want %+v", got, want)
+	}
+
+	// A response without the headers keeps the last known status
+	withHeaders = false
+	if _, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.LastRateLimit(); got != want {
+		t.Errorf("LastRateLimit after a response without headers = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const redacted = "***"
+
+// sensitiveKeyParts are substrings of field names whose values must never be logged
+var sensitiveKeyParts = []string{"password", "token", "secret", "authorization"}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue returns a copy of a decoded JSON value with sensitive fields replaced
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				out[key] = redacted
+			} else {
+				out[key] = redactValue(item)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = redactValue(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// sensitivePairPattern matches "key": "value" pairs with a sensitive key in text
+// that is not valid JSON, such as a truncated body. The closing quote is
+// optional so a value cut off mid-way is still caught.
+var sensitivePairPattern = regexp.MustCompile(`(?i)("[^"]*(?:` + strings.Join(sensitiveKeyParts, "|") + `)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// redactJSON returns a JSON body with sensitive fields replaced. Bodies that
+// aren't valid JSON have sensitive string fields masked on a best-effort basis.
+func redactJSON(body []byte) string {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return sensitivePairPattern.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+	}
+
+	redactedBody, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return string(body)
+	}
+	return string(redactedBody)
+}
+
+// redactingLogger wraps a Logger and masks secrets and sensitive fields
+// before anything reaches it
+type redactingLogger struct {
+	next    Logger
+	secrets []string
+}
+
+func newRedactingLogger(next Logger, secrets ...string) *redactingLogger {
+	l := &redactingLogger{next: next}
+	for _, secret := range secrets {
+		if secret != "" {
+			l.secrets = append(l.secrets, secret)
+		}
+	}
+	return l
+}
+
+// redact masks registered secrets in a string
+func (l *redactingLogger) redact(s string) string {
+	for _, secret := range l.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// redactFields masks the values of key/value pairs whose key looks sensitive
+// and any registered secrets inside string values
+func (l *redactingLogger) redactFields(fields []interface{}) []interface{} {
+	out := make([]interface{}, len(fields))
+	for i, field := range fields {
+		if s, ok := field.(string); ok {
+			field = l.redact(s)
+		}
+		out[i] = field
+	}
+	for i := 0; i+1 < len(out); i += 2 {
+		if key, ok := out[i].(string); ok && isSensitiveKey(key) {
+			out[i+1] = redacted
+		}
+	}
+	return out
+}
+
+func (l *redactingLogger) Debug(message string, fields ...interface{}) {
+	l.next.Debug(l.redact(message), l.redactFields(fields)...)
+}
+
+func (l *redactingLogger) Info(message string, fields ...interface{}) {
+	l.next.Info(l.redact(message), l.redactFields(fields)...)
+}
+
+func (l *redactingLogger) Warn(message string, fields ...interface{}) {
+	l.next.Warn(l.redact(message), l.redactFields(fields)...)
+}
+
+func (l *redactingLogger) Error(message string, err error, fields ...interface{}) {
+	if err != nil {
+		if msg := l.redact(err.Error()); msg != err.Error() {
+			err = errors.New(msg)
+		}
+	}
+	l.next.Error(l.redact(message), err, l.redactFields(fields)...)
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger keeps every logged line for inspection
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, message string, err error, fields []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf("%s %s %v %v", level, message, err, fields))
+}
+
+func (l *recordingLogger) Debug(message string, fields ...interface{}) {
+	l.record("DEBUG", message, nil, fields)
+}
+func (l *recordingLogger) Info(message string, fields ...interface{}) {
+	l.record("INFO", message, nil, fields)
+}
+func (l *recordingLogger) Warn(message string, fields ...interface{}) {
+	l.record("WARN", message, nil, fields)
+}
+func (l *recordingLogger) Error(message string, err error, fields ...interface{}) {
+	l.record("ERROR", message, err, fields)
+}
+
+func (l *recordingLogger) output() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"valid JSON", `{"id":"S1","paymentToken":"tok_live_1"}`, `{"id":"S1","paymentToken":"***"}`},
+		{"nested", `{"card":{"password":"hunter2"}}`, `{"card":{"password":"***"}}`},
+		{"truncated JSON", `{"id":"S1","access_token":"eyJhbGciOi`, `{"id":"S1","access_token":"***"`},
+		{"not JSON", `<html>Bad Gateway</html>`, `<html>Bad Gateway</html>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactJSON([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactJSON(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFailuresDoNotLeakSecrets(t *testing.T) {
+	const secret = "tok_live_secret"
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"wrong type", `{"id":"S1","quantity":"many","paymentToken":"` + secret + `"}`},
+		{"truncated", `{"id":"S1","paymentToken":"` + secret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &recordingLogger{}
+			c := newTestClient(t, jsonHandler(http.StatusOK, tt.body), WithLogger(logger))
+
+			_, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("error = %v, want a *ParseError", err)
+			}
+			if strings.Contains(parseErr.Snippet, secret) {
+				t.Errorf("ParseError snippet %q contains the secret", parseErr.Snippet)
+			}
+			if out := logger.output(); strings.Contains(out, secret) {
+				t.Errorf("log contains the secret:\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// months returns the length of the billing cycle in months, or 0 for cycles not
+// measured in months
+func (b BillingCycle) months() int {
+	switch b {
+	case BillingMonthly:
+		return 1
+	case BillingQuarterly:
+		return 3
+	case BillingYearly:
+		return 12
+	}
+	return 0
+}
+
+// NextBillingDates projects the next n billing dates, starting with
+// NextBillingDate, from the subscription's billing cycle. Renewals stay anchored
+// to the day of month of NextBillingDate, clamped to shorter months, so a
+// monthly subscription billed on Jan 31 renews on Feb 28 (Feb 29 in leap years),
+// then Mar 31. It returns nil when NextBillingDate is unset or the billing cycle
+// is unknown. No API call is made.
+func (s *Subscription) NextBillingDates(n int) []time.Time {
+	if n <= 0 || s.NextBillingDate.IsZero() {
+		return nil
+	}
+
+	anchor := s.NextBillingDate.Time
+	months := s.BillingCycle.months()
+	if months == 0 && s.BillingCycle != BillingWeekly {
+		return nil
+	}
+
+	dates := make([]time.Time, n)
+	for i := range dates {
+		if s.BillingCycle == BillingWeekly {
+			dates[i] = anchor.AddDate(0, 0, 7*i)
+		} else {
+			dates[i] = addMonthsClamped(anchor, months*i)
+		}
+	}
+	return dates
+}
+
+// addMonthsClamped adds months to t, clamping the day to the last day of the
+// resulting month instead of overflowing into the next one like time.AddDate
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+
+	first := time.Date(year, month+time.Month(months), 1, hour, minute, sec, t.Nanosecond(), t.Location())
+	if last := daysIn(first.Year(), first.Month()); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// daysIn returns the number of days in the given month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// GetRenewalsDue returns the customer's subscriptions whose next billing date
+// falls within the given window from now, nearest renewal first. Cancelled and
+// expired subscriptions, and those with a pending cancellation, are left out.
+// The API has no billing date filter, so all of the customer's subscriptions
+// are fetched and filtered client-side.
+func (c *BaseClient) GetRenewalsDue(ctx context.Context, customerID string, within time.Duration, opts ...CallOption) ([]Subscription, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customer ID is required")
+	}
+	if within < 0 {
+		return nil, fmt.Errorf("renewal window must not be negative")
+	}
+
+	subscriptions, err := c.GetSubscriptionsForCustomer(ctx, customerID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	from := c.clock.Now()
+	to := from.Add(within)
+
+	var due []Subscription
+	for _, s := range subscriptions {
+		if s.Status == StatusCancelled || s.Status == StatusExpired || s.CancelledAt != nil {
+			continue
+		}
+		next := s.NextBillingDate.Time
+		if next.IsZero() || next.Before(from) || next.After(to) {
+			continue
+		}
+		due = append(due, s)
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].NextBillingDate.Before(due[j].NextBillingDate.Time)
+	})
+
+	c.log(ctx).Info("Found subscriptions due for renewal",
+		"customer_id", customerID,
+		"within", within.String(),
+		"subscriptions_count", len(due))
+
+	return due, nil
+}
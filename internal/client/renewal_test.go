@@ -0,0 +1,82 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNextBillingDates(t *testing.T) {
+	tests := []struct {
+		name  string
+		cycle BillingCycle
+		start time.Time
+		want  []time.Time
+	}{
+		{"monthly from Jan 31 in a leap year", BillingMonthly, date(2024, 1, 31),
+			[]time.Time{date(2024, 1, 31), date(2024, 2, 29), date(2024, 3, 31), date(2024, 4, 30), date(2024, 5, 31)}},
+		{"monthly from Jan 31 in a common year", BillingMonthly, date(2023, 1, 31),
+			[]time.Time{date(2023, 1, 31), date(2023, 2, 28), date(2023, 3, 31), date(2023, 4, 30), date(2023, 5, 31)}},
+		{"monthly from the 30th", BillingMonthly, date(2023, 12, 30),
+			[]time.Time{date(2023, 12, 30), date(2024, 1, 30), date(2024, 2, 29), date(2024, 3, 30), date(2024, 4, 30)}},
+		{"quarterly from Nov 30", BillingQuarterly, date(2023, 11, 30),
+			[]time.Time{date(2023, 11, 30), date(2024, 2, 29), date(2024, 5, 30), date(2024, 8, 30), date(2024, 11, 30)}},
+		{"quarterly from Aug 31", BillingQuarterly, date(2024, 8, 31),
+			[]time.Time{date(2024, 8, 31), date(2024, 11, 30), date(2025, 2, 28), date(2025, 5, 31), date(2025, 8, 31)}},
+		{"yearly from Feb 29", BillingYearly, date(2024, 2, 29),
+			[]time.Time{date(2024, 2, 29), date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28), date(2028, 2, 29)}},
+		{"yearly across the 2100 non-leap century", BillingYearly, date(2096, 2, 29),
+			[]time.Time{date(2096, 2, 29), date(2097, 2, 28), date(2098, 2, 28), date(2099, 2, 28), date(2100, 2, 28)}},
+		{"weekly across Feb 29", BillingWeekly, date(2024, 2, 22),
+			[]time.Time{date(2024, 2, 22), date(2024, 2, 29), date(2024, 3, 7), date(2024, 3, 14), date(2024, 3, 21)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Subscription{BillingCycle: tt.cycle, NextBillingDate: CBTime{tt.start}}
+			got := s.NextBillingDates(len(tt.want))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d dates, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("date %d = %s, want %s", i, got[i].Format(dateFormat), tt.want[i].Format(dateFormat))
+				}
+			}
+		})
+	}
+}
+
+func TestNextBillingDatesKeepsTimeOfDay(t *testing.T) {
+	start := time.Date(2024, 1, 31, 23, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	s := &Subscription{BillingCycle: BillingMonthly, NextBillingDate: CBTime{start}}
+
+	got := s.NextBillingDates(2)[1]
+	want := time.Date(2024, 2, 29, 23, 30, 0, 0, start.Location())
+	if !got.Equal(want) {
+		t.Errorf("second date = %v, want %v", got, want)
+	}
+}
+
+func TestNextBillingDatesWithoutSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Subscription
+		n    int
+	}{
+		{"no next billing date", Subscription{BillingCycle: BillingMonthly}, 3},
+		{"unknown cycle", Subscription{BillingCycle: "biennial", NextBillingDate: CBTime{date(2024, 1, 1)}}, 3},
+		{"zero n", Subscription{BillingCycle: BillingMonthly, NextBillingDate: CBTime{date(2024, 1, 1)}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.NextBillingDates(tt.n); got != nil {
+				t.Errorf("NextBillingDates(%d) = %v, want nil", tt.n, got)
+			}
+		})
+	}
+}
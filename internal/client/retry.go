@@ -0,0 +1,132 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	// maxRetryDelay caps the backoff between two attempts
+	maxRetryDelay = 30 * time.Second
+	// maxMaxRetries is the highest MaxRetries accepted by Validate
+	maxMaxRetries = 10
+)
+
+// isSafeMethod reports whether a request with the given method can be repeated
+// without side effects
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// maxRetries returns how many times a request with the given method may be retried.
+// Only safe methods are retried by default. Others, such as the POSTs behind
+// cancellations and refunds, are retried only with WithRetryUnsafe or when
+// RetryNonIdempotent is set; they always carry an idempotency key. WithNoRetry
+// turns retries off for a single call.
+func (c *BaseClient) maxRetries(method string, callOpts *callOptions) int {
+	if callOpts.noRetry {
+		return 0
+	}
+	if !isSafeMethod(method) && !callOpts.retryUnsafe && !c.config.RetryNonIdempotent {
+		return 0
+	}
+	if c.config.MaxRetries < 0 {
+		return 0
+	}
+	if c.config.MaxRetries == 0 {
+		return defaultMaxRetries
+	}
+	return c.config.MaxRetries
+}
+
+// retryDelay returns the exponential backoff delay with jitter for the given
+// attempt, capped at maxRetryDelay
+func (c *BaseClient) retryDelay(attempt int) time.Duration {
+	base := c.config.RetryBaseDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+	backoff := maxRetryDelay
+	// Shifting further would overflow, or exceed the cap anyway
+	if attempt < 32 && base <= maxRetryDelay>>attempt {
+		backoff = base << attempt
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// shouldRetry reports whether a request failing with the given status code or
+// transport error is worth retrying
+func shouldRetry(statusCode int, err error) bool {
+	if errors.Is(err, ErrResponseTooLarge) {
+		// The same response would be downloaded again
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RateLimitError is returned when the API responds with 429 Too Many Requests.
+// RetryAfter holds the delay requested by the Retry-After header, or zero if none was sent.
+// Requests are not retried automatically when it exceeds 30 seconds.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited, retry after %s: %v", e.RetryAfter, e.Err)
+	}
+	return fmt.Sprintf("rate limited: %v", e.Err)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// parseRetryAfter parses a Retry-After header value given either as
+// delay-seconds or as an HTTP-date. It returns zero if the value is missing or invalid.
+// Delays too long for a time.Duration are clamped to the largest one.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		if int64(seconds) > math.MaxInt64/int64(time.Second) {
+			return math.MaxInt64
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
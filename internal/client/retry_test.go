@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestRetryDelayIsCapped(t *testing.T) {
+	c := &BaseClient{config: &CleverbridgeConfig{RetryBaseDelay: 200 * time.Millisecond}}
+
+	for _, attempt := range []int{0, 5, 36, 63, 100} {
+		delay := c.retryDelay(attempt)
+		if delay <= 0 || delay > maxRetryDelay {
+			t.Errorf("retryDelay(%d) = %v, want within (0, %v]", attempt, delay, maxRetryDelay)
+		}
+	}
+}
+
+func TestValidateRejectsExcessiveMaxRetries(t *testing.T) {
+	cfg := &CleverbridgeConfig{ClientID: "id", ClientSecret: "secret", BaseURL: "https://api.test", MaxRetries: 1000}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("Validate() accepted max_retries 1000")
+	}
+}
+
+// countingHandler answers every request with status and counts the attempts
+func countingHandler(status int, attempts *int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(attempts, 1)
+		w.WriteHeader(status)
+	})
+}
+
+func withMaxRetries(n int) Option {
+	return func(c *BaseClient) { c.config.MaxRetries = n }
+}
+
+func TestPostIsNotRetried(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, countingHandler(http.StatusServiceUnavailable, &attempts), withMaxRetries(3))
+
+	if _, err := c.CancelSubscription(context.Background(), "S1", ""); err == nil {
+		t.Fatal("CancelSubscription succeeded, want the 503 error")
+	}
+	if attempts != 1 {
+		t.Errorf("POST sent %d times, want 1", attempts)
+	}
+}
+
+func TestGetIsRetried(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, countingHandler(http.StatusServiceUnavailable, &attempts), withMaxRetries(3))
+
+	if _, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent); err == nil {
+		t.Fatal("GetSubscription succeeded, want the 503 error")
+	}
+	if attempts != 4 {
+		t.Errorf("GET sent %d times, want 4", attempts)
+	}
+}
+
+func TestRetryUnsafeReusesIdempotencyKey(t *testing.T) {
+	var attempts int32
+	keys := map[string]bool{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		keys[r.Header.Get("Idempotency-Key")] = true
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}), withMaxRetries(2))
+
+	if _, err := c.CancelSubscription(context.Background(), "S1", "", WithRetryUnsafe()); err == nil {
+		t.Fatal("CancelSubscription succeeded, want the 503 error")
+	}
+	if attempts != 3 {
+		t.Errorf("POST sent %d times, want 3", attempts)
+	}
+	if len(keys) != 1 || keys[""] {
+		t.Errorf("Idempotency-Key values = %v, want one key on every attempt", keys)
+	}
+}
+
+func TestIdempotencyKeyFailureIsReturned(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, countingHandler(http.StatusOK, &attempts))
+
+	randErr := errors.New("entropy unavailable")
+	randReader = iotest.ErrReader(randErr)
+	t.Cleanup(func() { randReader = rand.Reader })
+
+	if _, err := c.CancelSubscription(context.Background(), "S1", ""); !errors.Is(err, randErr) {
+		t.Errorf("error = %v, want it to wrap %v", err, randErr)
+	}
+	if attempts != 0 {
+		t.Errorf("POST sent %d times without an idempotency key, want 0", attempts)
+	}
+}
+
+func TestWithNoRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(c *BaseClient) error
+	}{
+		{"GET", func(c *BaseClient) error {
+			_, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent, WithNoRetry())
+			return err
+		}},
+		{"POST with WithRetryUnsafe", func(c *BaseClient) error {
+			_, err := c.CancelSubscription(context.Background(), "S1", "", WithRetryUnsafe(), WithNoRetry())
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			c := newTestClient(t, countingHandler(http.StatusServiceUnavailable, &attempts), withMaxRetries(3))
+
+			if err := tt.call(c); err == nil {
+				t.Fatal("call succeeded, want the 503 error")
+			}
+			if attempts != 1 {
+				t.Errorf("request sent %d times, want 1", attempts)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfterHugeValue(t *testing.T) {
+	if got := parseRetryAfter("99999999999999999", time.Now()); got <= 0 {
+		t.Errorf("parseRetryAfter of a huge value = %v, want a positive delay", got)
+	}
+}
+
+func TestRetryAfterBeyondCapIsNotWaited(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "99999999999999999")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}), withMaxRetries(3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := c.GetSubscription(ctx, "S1", SubscriptionViewCurrent)
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("error = %v, want a *RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter <= maxRetryDelay {
+		t.Errorf("RetryAfter = %v, want the requested delay beyond %v", rateLimitErr.RetryAfter, maxRetryDelay)
+	}
+	if attempts != 1 {
+		t.Errorf("GET sent %d times, want 1", attempts)
+	}
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// beginRequest registers an in-flight request, or fails once Shutdown has been called
+func (c *BaseClient) beginRequest() error {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+
+	if c.shuttingDown {
+		return ErrClientClosed
+	}
+	c.inFlight.Add(1)
+	return nil
+}
+
+// Shutdown stops the client from accepting new requests, which then fail with
+// ErrClientClosed. It waits for in-flight requests, including their retries, to
+// finish and then closes the logger. If ctx expires first, Shutdown returns the
+// context error and leaves the logger open for the requests still running.
+func (c *BaseClient) Shutdown(ctx context.Context) error {
+	c.lifecycleMu.Lock()
+	c.shuttingDown = true
+	c.lifecycleMu.Unlock()
+
+	c.logger.Info("Shutting down client, waiting for in-flight requests")
+
+	done := make(chan struct{})
+	go func() {
+		c.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		c.logger.Info("Client shut down")
+		return c.Close()
+	case <-ctx.Done():
+		c.logger.Warn("Client shutdown timed out with requests still in flight")
+		return fmt.Errorf("shutdown: %w", ctx.Err())
+	}
+}
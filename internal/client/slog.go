@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogger adapts a *slog.Logger to the Logger interface
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger wraps a *slog.Logger so it can be passed to WithLogger
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger}
+}
+
+// Enabled reports whether the underlying slog handler accepts the given level
+func (l *SlogLogger) Enabled(level LogLevel) bool {
+	return l.logger.Enabled(context.Background(), slogLevel(level))
+}
+
+func (l *SlogLogger) Debug(message string, fields ...interface{}) {
+	l.logger.Debug(message, fields...)
+}
+
+func (l *SlogLogger) Info(message string, fields ...interface{}) {
+	l.logger.Info(message, fields...)
+}
+
+func (l *SlogLogger) Warn(message string, fields ...interface{}) {
+	l.logger.Warn(message, fields...)
+}
+
+func (l *SlogLogger) Error(message string, err error, fields ...interface{}) {
+	if err != nil {
+		fields = append([]interface{}{"error", err}, fields...)
+	}
+	l.logger.Error(message, fields...)
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
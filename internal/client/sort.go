@@ -0,0 +1,66 @@
+package client
+
+import "sort"
+
+// SubscriptionSortField is a field subscriptions can be sorted by
+type SubscriptionSortField string
+
+const (
+	SortByCreatedAt       SubscriptionSortField = "createdAt"
+	SortByNextBillingDate SubscriptionSortField = "nextBillingDate"
+	// SortByAmount orders by currency code, then by amount within a currency
+	SortByAmount SubscriptionSortField = "amount"
+)
+
+// subscriptionSort is the order requested with WithSort
+type subscriptionSort struct {
+	field      SubscriptionSortField
+	descending bool
+}
+
+// defaultSubscriptionSort shows the newest subscriptions first
+var defaultSubscriptionSort = subscriptionSort{field: SortByCreatedAt, descending: true}
+
+// WithSort orders the subscriptions returned by GetSubscriptionsForCustomer. The
+// order is sent to the API and also applied client-side, so the result is sorted
+// even where the API ignores it. The default is newest first.
+func WithSort(field SubscriptionSortField, descending bool) CallOption {
+	return func(o *callOptions) {
+		o.sort = &subscriptionSort{field: field, descending: descending}
+	}
+}
+
+func (s subscriptionSort) addTo(queryParams query) {
+	order := "asc"
+	if s.descending {
+		order = "desc"
+	}
+	queryParams.
+		set("sortBy", string(s.field)).
+		set("sortOrder", order)
+}
+
+// apply sorts subscriptions in place. Ties keep the order the API returned.
+func (s subscriptionSort) apply(subscriptions []Subscription) {
+	less := func(a, b *Subscription) bool {
+		switch s.field {
+		case SortByNextBillingDate:
+			return a.NextBillingDate.Before(b.NextBillingDate.Time)
+		case SortByAmount:
+			// Amounts in different currencies are not comparable, so
+			// subscriptions are grouped by currency first
+			if a.Currency != b.Currency {
+				return a.Currency < b.Currency
+			}
+			return a.Amount < b.Amount
+		default:
+			return a.CreatedAt.Before(b.CreatedAt.Time)
+		}
+	}
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		if s.descending {
+			return less(&subscriptions[j], &subscriptions[i])
+		}
+		return less(&subscriptions[i], &subscriptions[j])
+	})
+}
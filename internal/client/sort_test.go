@@ -0,0 +1,26 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortByAmountGroupsCurrencies(t *testing.T) {
+	subscriptions := []Subscription{
+		{ID: "S1", Amount: MoneyFromFloat(50), Currency: "USD"},
+		{ID: "S2", Amount: MoneyFromFloat(1000), Currency: "JPY"},
+		{ID: "S3", Amount: MoneyFromFloat(20), Currency: "EUR"},
+		{ID: "S4", Amount: MoneyFromFloat(10), Currency: "USD"},
+		{ID: "S5", Amount: MoneyFromFloat(30), Currency: "EUR"},
+	}
+
+	subscriptionSort{field: SortByAmount}.apply(subscriptions)
+	if got, want := subscriptionIDs(subscriptions), []string{"S3", "S5", "S2", "S4", "S1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ascending = %v, want %v", got, want)
+	}
+
+	subscriptionSort{field: SortByAmount, descending: true}.apply(subscriptions)
+	if got, want := subscriptionIDs(subscriptions), []string{"S1", "S4", "S2", "S5", "S3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("descending = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SubscriptionStatus is the lifecycle state of a subscription
+type SubscriptionStatus string
+
+const (
+	StatusActive    SubscriptionStatus = "active"
+	StatusTrial     SubscriptionStatus = "trial"
+	StatusPastDue   SubscriptionStatus = "past_due"
+	StatusPaused    SubscriptionStatus = "paused"
+	StatusCancelled SubscriptionStatus = "cancelled"
+	StatusExpired   SubscriptionStatus = "expired"
+	StatusUnknown   SubscriptionStatus = "unknown"
+)
+
+// subscriptionStatuses maps status spellings, compared case-insensitively, to a status
+var subscriptionStatuses = map[string]SubscriptionStatus{
+	"active":    StatusActive,
+	"trial":     StatusTrial,
+	"past_due":  StatusPastDue,
+	"pastdue":   StatusPastDue,
+	"paused":    StatusPaused,
+	"cancelled": StatusCancelled,
+	"canceled":  StatusCancelled,
+	"expired":   StatusExpired,
+}
+
+// IsActive reports whether the subscription is currently granting access,
+// including during a trial and while a payment is past due
+func (s SubscriptionStatus) IsActive() bool {
+	return s == StatusActive || s == StatusTrial || s == StatusPastDue
+}
+
+// UnmarshalJSON maps unknown statuses to StatusUnknown instead of failing
+func (s *SubscriptionStatus) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = parseSubscriptionStatus(raw)
+	return nil
+}
+
+func parseSubscriptionStatus(raw string) SubscriptionStatus {
+	if raw == "" {
+		return ""
+	}
+	if status, ok := subscriptionStatuses[strings.ToLower(strings.TrimSpace(raw))]; ok {
+		return status
+	}
+	return StatusUnknown
+}
+
+// BillingCycle is how often a subscription renews
+type BillingCycle string
+
+const (
+	BillingWeekly    BillingCycle = "weekly"
+	BillingMonthly   BillingCycle = "monthly"
+	BillingQuarterly BillingCycle = "quarterly"
+	BillingYearly    BillingCycle = "yearly"
+	BillingUnknown   BillingCycle = "unknown"
+)
+
+// billingCycles maps billing cycle spellings, compared case-insensitively, to a cycle
+var billingCycles = map[string]BillingCycle{
+	"weekly":    BillingWeekly,
+	"monthly":   BillingMonthly,
+	"quarterly": BillingQuarterly,
+	"yearly":    BillingYearly,
+	"annual":    BillingYearly,
+	"annually":  BillingYearly,
+}
+
+// UnmarshalJSON maps unknown billing cycles to BillingUnknown instead of failing
+func (b *BillingCycle) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*b = parseBillingCycle(raw)
+	return nil
+}
+
+func parseBillingCycle(raw string) BillingCycle {
+	if raw == "" {
+		return ""
+	}
+	if cycle, ok := billingCycles[strings.ToLower(strings.TrimSpace(raw))]; ok {
+		return cycle
+	}
+	return BillingUnknown
+}
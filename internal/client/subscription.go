@@ -0,0 +1,682 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dateFormat is the date-only format Cleverbridge expects in query parameters
+const dateFormat = "2006-01-02"
+
+// GetSubscription fetches a subscription with either its current or its
+// originally purchased terms, as selected by view.
+func (c *BaseClient) GetSubscription(ctx context.Context, subscriptionID string, view SubscriptionView, opts ...CallOption) (*Subscription, error) {
+	subscription, _, err := c.GetSubscriptionRaw(ctx, subscriptionID, view, opts...)
+	return subscription, err
+}
+
+// GetSubscriptionRaw is GetSubscription that also returns the response body as
+// received, e.g. to store the original payload for audit including fields the
+// Subscription type does not model.
+func (c *BaseClient) GetSubscriptionRaw(ctx context.Context, subscriptionID string, view SubscriptionView, opts ...CallOption) (*Subscription, json.RawMessage, error) {
+	isCurrent, err := view.isCurrent()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.log(ctx).Info("Getting subscription",
+		"subscription_id", subscriptionID,
+		"view", string(view))
+
+	queryParams := query{}.
+		set("subscriptionId", subscriptionID).
+		set("isCurrent", isCurrent)
+
+	resp, err := c.sendRequest(ctx, "GET", "/subscription/getsubscription", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get subscription", err,
+			"subscription_id", subscriptionID)
+		return nil, nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse subscription response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved subscription",
+		"subscription_id", subscription.ID,
+		"status", subscription.Status,
+		"plan", subscription.Plan)
+
+	return &subscription, json.RawMessage(resp.Body), nil
+}
+
+// GetSubscriptionExpanded fetches a subscription together with its customer and
+// product in one round trip. Without a WithExpand option both are expanded.
+func (c *BaseClient) GetSubscriptionExpanded(ctx context.Context, subscriptionID string, view SubscriptionView, opts ...CallOption) (*ExpandedSubscription, error) {
+	isCurrent, err := view.isCurrent()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(newCallOptions(opts).expand) == 0 {
+		opts = append(opts, WithExpand("customer", "product"))
+	}
+
+	c.log(ctx).Info("Getting expanded subscription",
+		"subscription_id", subscriptionID,
+		"view", string(view))
+
+	queryParams := query{}.
+		set("subscriptionId", subscriptionID).
+		set("isCurrent", isCurrent)
+
+	resp, err := c.sendRequest(ctx, "GET", "/subscription/getsubscription", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get expanded subscription", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	var subscription ExpandedSubscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse expanded subscription response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved expanded subscription",
+		"subscription_id", subscription.ID,
+		"status", subscription.Status,
+		"has_customer", subscription.Customer != nil,
+		"has_product", subscription.Product != nil)
+
+	return &subscription, nil
+}
+
+func (c *BaseClient) GetSubscriptionsByPurchase(ctx context.Context, purchaseID string, opts ...CallOption) ([]Subscription, error) {
+	c.log(ctx).Info("Getting subscriptions by purchase", "purchase_id", purchaseID)
+
+	queryParams := query{}.set("purchaseId", purchaseID)
+
+	subscriptions, err := paginate[Subscription](ctx, c, "/subscription/getsubscriptionsbypurchase", queryParams, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get subscriptions by purchase", err,
+			"purchase_id", purchaseID)
+		return nil, fmt.Errorf("failed to get subscriptions by purchase: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved subscriptions by purchase",
+		"purchase_id", purchaseID,
+		"subscriptions_count", len(subscriptions))
+
+	return subscriptions, nil
+}
+
+// GetSubscriptionsForCustomer returns all subscriptions of a customer, newest first
+// unless another order is requested with WithSort.
+func (c *BaseClient) GetSubscriptionsForCustomer(ctx context.Context, customerID string, opts ...CallOption) ([]Subscription, error) {
+	c.log(ctx).Info("Getting subscriptions for customer", "customer_id", customerID)
+
+	order := defaultSubscriptionSort
+	if s := newCallOptions(opts).sort; s != nil {
+		order = *s
+	}
+
+	queryParams := query{}.set("customerId", customerID)
+	order.addTo(queryParams)
+
+	subscriptions, err := paginate[Subscription](ctx, c, "/subscription/getsubscriptionsforcustomer", queryParams, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get subscriptions for customer", err,
+			"customer_id", customerID)
+		return nil, fmt.Errorf("failed to get subscriptions for customer: %w", err)
+	}
+	order.apply(subscriptions)
+
+	c.log(ctx).Info("Successfully retrieved subscriptions for customer",
+		"customer_id", customerID,
+		"subscriptions_count", len(subscriptions))
+
+	return subscriptions, nil
+}
+
+// CancelSubscription cancels a subscription, optionally with a Cleverbridge cancellation reason code.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) CancelSubscription(ctx context.Context, subscriptionID string, reason string, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+
+	c.log(ctx).Info("Cancelling subscription",
+		"subscription_id", subscriptionID,
+		"reason", reason)
+
+	body := cancelSubscriptionRequest{
+		SubscriptionID:     subscriptionID,
+		CancellationReason: reason,
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/cancelsubscription", nil, body, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to cancel subscription", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse cancel subscription response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully cancelled subscription",
+		"subscription_id", subscription.ID,
+		"status", subscription.Status)
+
+	return &subscription, nil
+}
+
+// PauseSubscription pauses recurring billing. A zero resumeDate pauses indefinitely.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) PauseSubscription(ctx context.Context, subscriptionID string, resumeDate time.Time, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+
+	c.log(ctx).Info("Pausing subscription",
+		"subscription_id", subscriptionID,
+		"resume_date", resumeDate)
+
+	queryParams := query{}.
+		set("subscriptionId", subscriptionID).
+		set("resumeDate", resumeDate)
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/pausesubscription", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to pause subscription", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to pause subscription: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse pause subscription response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	// A paused subscription is next billed when it resumes, or never if paused
+	// indefinitely. Fill that in only when the response leaves it out.
+	if subscription.NextBillingDate.IsZero() {
+		subscription.NextBillingDate = CBTime{resumeDate}
+	}
+
+	c.log(ctx).Info("Successfully paused subscription",
+		"subscription_id", subscription.ID,
+		"status", subscription.Status,
+		"next_billing_date", subscription.NextBillingDate)
+
+	return &subscription, nil
+}
+
+// ResumeSubscription resumes recurring billing of a paused subscription.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) ResumeSubscription(ctx context.Context, subscriptionID string, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+
+	c.log(ctx).Info("Resuming subscription", "subscription_id", subscriptionID)
+
+	queryParams := query{}.set("subscriptionId", subscriptionID)
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/resumesubscription", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to resume subscription", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to resume subscription: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse resume subscription response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully resumed subscription",
+		"subscription_id", subscription.ID,
+		"status", subscription.Status,
+		"next_billing_date", subscription.NextBillingDate)
+
+	return &subscription, nil
+}
+
+// ReactivateSubscription reactivates a cancelled subscription instead of creating
+// a new one. The returned subscription is active again with a recalculated
+// NextBillingDate. If Cleverbridge refuses, e.g. because the subscription is not
+// cancelled or can no longer be reactivated, the returned error wraps an *APIError.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) ReactivateSubscription(ctx context.Context, subscriptionID string, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+
+	c.log(ctx).Info("Reactivating subscription", "subscription_id", subscriptionID)
+
+	body := reactivateSubscriptionRequest{
+		SubscriptionID: subscriptionID,
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/reactivatesubscription", nil, body, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to reactivate subscription", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to reactivate subscription: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse reactivate subscription response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully reactivated subscription",
+		"subscription_id", subscription.ID,
+		"status", subscription.Status,
+		"next_billing_date", subscription.NextBillingDate)
+
+	return &subscription, nil
+}
+
+// ChangeNextBillingDate moves the next billing date of a subscription without cancelling it.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) ChangeNextBillingDate(ctx context.Context, subscriptionID string, newDate time.Time, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+	if newDate.Before(c.clock.Now()) {
+		return nil, fmt.Errorf("next billing date %s is in the past", newDate.Format(dateFormat))
+	}
+
+	c.log(ctx).Info("Changing next billing date",
+		"subscription_id", subscriptionID,
+		"next_billing_date", newDate)
+
+	body := changeNextBillingDateRequest{
+		SubscriptionID:  subscriptionID,
+		NextBillingDate: newDate.UTC().Format(dateFormat),
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/changenextbillingdate", nil, body, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to change next billing date", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to change next billing date: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse change next billing date response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+	if subscription.NextBillingDate.IsZero() {
+		subscription.NextBillingDate = CBTime{newDate}
+	}
+
+	c.log(ctx).Info("Successfully changed next billing date",
+		"subscription_id", subscription.ID,
+		"next_billing_date", subscription.NextBillingDate)
+
+	return &subscription, nil
+}
+
+// GetSubscriptionsForCustomerPage fetches a single page of a customer's subscriptions
+func (c *BaseClient) GetSubscriptionsForCustomerPage(ctx context.Context, customerID string, opts PageOptions, callOpts ...CallOption) (*SubscriptionPage, error) {
+	opts = opts.normalize()
+
+	c.log(ctx).Info("Getting subscriptions page for customer",
+		"customer_id", customerID,
+		"page", opts.Page,
+		"page_size", opts.PageSize)
+
+	queryParams := query{}.set("customerId", customerID)
+	opts.addTo(queryParams)
+
+	resp, err := c.sendRequest(ctx, "GET", "/subscription/getsubscriptionsforcustomer", queryParams, nil, callOpts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get subscriptions page for customer", err,
+			"customer_id", customerID,
+			"page", opts.Page)
+		return nil, fmt.Errorf("failed to get subscriptions for customer: %w", err)
+	}
+
+	page, err := decodePage[Subscription](resp, (opts.Page-1)*opts.PageSize, opts.PageSize, c.config.StrictDecoding)
+	if err != nil {
+		c.log(ctx).Error("Failed to parse subscriptions page response", err,
+			"customer_id", customerID,
+			"page", opts.Page,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscriptions: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully retrieved subscriptions page for customer",
+		"customer_id", customerID,
+		"page", opts.Page,
+		"subscriptions_count", len(page.Items),
+		"total_count", page.TotalCount,
+		"has_more", page.HasMore)
+
+	return page, nil
+}
+
+// IterateSubscriptionsForCustomer calls fn for each of the customer's subscriptions,
+// requesting pages of pageSize (0 for the default) until the list is exhausted.
+// Pages are followed by cursor like paginate, or by page number while the API
+// reports hasMore or a larger totalCount. Only one page is held in memory.
+// Iteration stops at the first error returned by fn or when ctx is done.
+func (c *BaseClient) IterateSubscriptionsForCustomer(ctx context.Context, customerID string, pageSize int, fn func(Subscription) error) error {
+	opts := PageOptions{Page: 1, PageSize: pageSize}.normalize()
+
+	queryParams := query{}.set("customerId", customerID)
+	opts.addTo(queryParams)
+
+	return paginateFunc(ctx, c, "/subscription/getsubscriptionsforcustomer", queryParams, func(page []Subscription) error {
+		for _, subscription := range page {
+			if err := fn(subscription); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	})
+}
+
+// GetSubscriptionHistory returns the events of a subscription, oldest first
+func (c *BaseClient) GetSubscriptionHistory(ctx context.Context, subscriptionID string, opts ...CallOption) ([]SubscriptionEvent, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+
+	c.log(ctx).Info("Getting subscription history", "subscription_id", subscriptionID)
+
+	queryParams := query{}.set("subscriptionId", subscriptionID)
+
+	resp, err := c.sendRequest(ctx, "GET", "/subscription/getsubscriptionhistory", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to get subscription history", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to get subscription history: %w", err)
+	}
+
+	var events []SubscriptionEvent
+	if err := c.decodeJSON(resp, &events); err != nil {
+		c.log(ctx).Error("Failed to parse subscription history response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription history: %w", err)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp.Time)
+	})
+
+	c.log(ctx).Info("Successfully retrieved subscription history",
+		"subscription_id", subscriptionID,
+		"events_count", len(events))
+
+	return events, nil
+}
+
+// ChangeSubscriptionPlan upgrades or downgrades a subscription to another plan.
+// If Cleverbridge rejects the change, e.g. because the subscription isn't active,
+// the returned error wraps an *APIError.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) ChangeSubscriptionPlan(ctx context.Context, subscriptionID, newPlanID string, prorate bool, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+	if newPlanID == "" {
+		return nil, fmt.Errorf("plan ID is required")
+	}
+
+	c.log(ctx).Info("Changing subscription plan",
+		"subscription_id", subscriptionID,
+		"plan", newPlanID,
+		"prorate", prorate)
+
+	body := changeSubscriptionPlanRequest{
+		SubscriptionID: subscriptionID,
+		PlanID:         newPlanID,
+		Prorate:        prorate,
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/changesubscriptionplan", nil, body, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to change subscription plan", err,
+			"subscription_id", subscriptionID,
+			"plan", newPlanID)
+		return nil, fmt.Errorf("failed to change subscription plan: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse change subscription plan response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully changed subscription plan",
+		"subscription_id", subscription.ID,
+		"plan", subscription.Plan,
+		"amount", subscription.Amount,
+		"billing_cycle", subscription.BillingCycle)
+
+	return &subscription, nil
+}
+
+// ChangeSubscriptionQuantity changes the number of seats of a subscription. With
+// prorate set, the difference for the current period is charged or credited.
+// The returned subscription reflects the new quantity and amount.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) ChangeSubscriptionQuantity(ctx context.Context, subscriptionID string, newQuantity int, prorate bool, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+	if newQuantity < 1 {
+		return nil, fmt.Errorf("quantity must be at least 1, got %d", newQuantity)
+	}
+
+	c.log(ctx).Info("Changing subscription quantity",
+		"subscription_id", subscriptionID,
+		"quantity", newQuantity,
+		"prorate", prorate)
+
+	body := changeSubscriptionQuantityRequest{
+		SubscriptionID: subscriptionID,
+		Quantity:       newQuantity,
+		Prorate:        prorate,
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/changesubscriptionquantity", nil, body, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to change subscription quantity", err,
+			"subscription_id", subscriptionID,
+			"quantity", newQuantity)
+		return nil, fmt.Errorf("failed to change subscription quantity: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse change subscription quantity response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully changed subscription quantity",
+		"subscription_id", subscription.ID,
+		"quantity", subscription.Quantity,
+		"amount", subscription.Amount)
+
+	return &subscription, nil
+}
+
+// ChangeSubscriptionPaymentMethod attaches a new payment method, given as a payment
+// token, to a subscription. If the payment method is declined the returned error
+// is a *PaymentDeclinedError carrying the decline reason. The token is never logged.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) ChangeSubscriptionPaymentMethod(ctx context.Context, subscriptionID, paymentToken string, opts ...CallOption) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+	if paymentToken == "" {
+		return nil, fmt.Errorf("payment token is required")
+	}
+
+	c.log(ctx).Info("Changing subscription payment method", "subscription_id", subscriptionID)
+
+	body := changeSubscriptionPaymentMethodRequest{
+		SubscriptionID: subscriptionID,
+		PaymentToken:   paymentToken,
+	}
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/changepaymentmethod", nil, body, opts...)
+	if err != nil {
+		err = asPaymentDeclined(err)
+		c.log(ctx).Error("Failed to change subscription payment method", err,
+			"subscription_id", subscriptionID)
+		return nil, fmt.Errorf("failed to change subscription payment method: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse change payment method response", err,
+			"subscription_id", subscriptionID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully changed subscription payment method",
+		"subscription_id", subscription.ID,
+		"status", subscription.Status)
+
+	return &subscription, nil
+}
+
+// CreateSubscription purchases a new subscription on behalf of a customer.
+// A zero Quantity is sent as 1.
+// It is not retried on transient failures unless WithRetryUnsafe is given.
+func (c *BaseClient) CreateSubscription(ctx context.Context, req CreateSubscriptionRequest, opts ...CallOption) (*Subscription, error) {
+	var missing []string
+	if req.CustomerID == "" {
+		missing = append(missing, "customer ID")
+	}
+	if req.ProductID == "" {
+		missing = append(missing, "product ID")
+	}
+	if req.Currency == "" {
+		missing = append(missing, "currency")
+	}
+	if req.PaymentToken == "" {
+		missing = append(missing, "payment token")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+	if req.Quantity < 0 {
+		return nil, fmt.Errorf("quantity must be at least 1, got %d", req.Quantity)
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+
+	c.log(ctx).Info("Creating subscription",
+		"customer_id", req.CustomerID,
+		"product_id", req.ProductID,
+		"quantity", req.Quantity,
+		"currency", req.Currency)
+
+	resp, err := c.sendRequest(ctx, "POST", "/subscription/createsubscription", nil, req, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to create subscription", err,
+			"customer_id", req.CustomerID,
+			"product_id", req.ProductID)
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	var subscription Subscription
+	if err := c.decodeJSON(resp, &subscription); err != nil {
+		c.log(ctx).Error("Failed to parse create subscription response", err,
+			"customer_id", req.CustomerID,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscription: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully created subscription",
+		"subscription_id", subscription.ID,
+		"customer_id", subscription.CustomerID,
+		"status", subscription.Status)
+
+	return &subscription, nil
+}
+
+// ListSubscriptions returns one page of the account's subscriptions matching filter
+func (c *BaseClient) ListSubscriptions(ctx context.Context, filter SubscriptionFilter, opts ...CallOption) (*SubscriptionPage, error) {
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && filter.CreatedTo.Before(filter.CreatedFrom) {
+		return nil, fmt.Errorf("created date range is empty: %s is before %s",
+			filter.CreatedTo.Format(dateFormat), filter.CreatedFrom.Format(dateFormat))
+	}
+	filter.PageOptions = filter.PageOptions.normalize()
+
+	c.log(ctx).Info("Listing subscriptions",
+		"status", filter.Status,
+		"product_id", filter.ProductID,
+		"billing_cycle", filter.BillingCycle,
+		"page", filter.Page,
+		"page_size", filter.PageSize)
+
+	queryParams := query{}
+	filter.addTo(queryParams)
+
+	resp, err := c.sendRequest(ctx, "GET", "/subscription/getsubscriptions", queryParams, nil, opts...)
+	if err != nil {
+		c.log(ctx).Error("Failed to list subscriptions", err,
+			"page", filter.Page)
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	page, err := decodePage[Subscription](resp, (filter.Page-1)*filter.PageSize, filter.PageSize, c.config.StrictDecoding)
+	if err != nil {
+		c.log(ctx).Error("Failed to parse subscriptions page response", err,
+			"page", filter.Page,
+			"response_body", c.logBody(resp.Body))
+		return nil, fmt.Errorf("failed to parse subscriptions: %w", err)
+	}
+
+	c.log(ctx).Info("Successfully listed subscriptions",
+		"page", filter.Page,
+		"subscriptions_count", len(page.Items),
+		"total_count", page.TotalCount,
+		"has_more", page.HasMore)
+
+	return page, nil
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	var got CreateSubscriptionRequest
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/subscription/createsubscription" {
+			t.Errorf("request = %s %s, want POST /subscription/createsubscription", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"S100","status":"active","customerId":"C1","productId":"P1","quantity":1,"currency":"EUR"}`))
+	}))
+
+	subscription, err := c.CreateSubscription(context.Background(), CreateSubscriptionRequest{
+		CustomerID:   "C1",
+		ProductID:    "P1",
+		Currency:     "EUR",
+		PaymentToken: "tok_123",
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription error: %v", err)
+	}
+	if subscription.ID != "S100" || subscription.Status != StatusActive {
+		t.Errorf("subscription = %+v, want S100 active", subscription)
+	}
+	want := CreateSubscriptionRequest{CustomerID: "C1", ProductID: "P1", Quantity: 1, Currency: "EUR", PaymentToken: "tok_123"}
+	if got != want {
+		t.Errorf("request body = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateSubscriptionValidatesBeforeSending(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+
+	_, err := c.CreateSubscription(context.Background(), CreateSubscriptionRequest{ProductID: "P1"})
+	if err == nil || !strings.Contains(err.Error(), "customer ID, currency, payment token") {
+		t.Errorf("error = %v, want the missing fields listed", err)
+	}
+}
+
+func TestGetSubscription(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subscription/getsubscription" {
+			t.Errorf("path = %q, want /subscription/getsubscription", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("subscriptionId"); got != "S1" {
+			t.Errorf("subscriptionId = %q, want S1", got)
+		}
+		if got := r.URL.Query().Get("isCurrent"); got != "true" {
+			t.Errorf("isCurrent = %q, want true", got)
+		}
+		w.Write([]byte(`{"id":"S1","status":"active","plan":"pro","amount":19.99,"currency":"EUR"}`))
+	}))
+
+	subscription, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+	if err != nil {
+		t.Fatalf("GetSubscription error: %v", err)
+	}
+	if subscription.ID != "S1" || subscription.Plan != "pro" || subscription.Amount != 1999 {
+		t.Errorf("subscription = %+v", subscription)
+	}
+}
+
+func TestGetSubscriptionNotFound(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusNotFound, `{"error":"not_found","message":"Subscription not found"}`))
+
+	_, err := c.GetSubscription(context.Background(), "S404", SubscriptionViewCurrent)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("error = %v, want ErrNotFound", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("error = %v, want a 404 *APIError", err)
+	}
+}
+
+func TestGetSubscriptionMalformedJSON(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"id":"S1",`))
+
+	_, err := c.GetSubscription(context.Background(), "S1", SubscriptionViewCurrent)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("error = %v, want a *ParseError", err)
+	}
+	if parseErr.Endpoint != "/subscription/getsubscription" || parseErr.Snippet != `{"id":"S1",` {
+		t.Errorf("ParseError = %+v", parseErr)
+	}
+}
+
+func TestGetSubscriptionInvalidView(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for an invalid view")
+	}))
+
+	if _, err := c.GetSubscription(context.Background(), "S1", SubscriptionView("latest")); err == nil {
+		t.Error("GetSubscription accepted an invalid view")
+	}
+}
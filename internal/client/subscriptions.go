@@ -0,0 +1,40 @@
+package client
+
+import "strings"
+
+// Subscriptions adds helpers to a slice of subscriptions as returned by the list
+// methods, e.g. client.Subscriptions(subs).FilterByStatus(client.StatusActive).
+// None of them call the API.
+type Subscriptions []Subscription
+
+// FilterByStatus returns the subscriptions with the given status, in order
+func (s Subscriptions) FilterByStatus(status SubscriptionStatus) Subscriptions {
+	var filtered Subscriptions
+	for _, subscription := range s {
+		if subscription.Status == status {
+			filtered = append(filtered, subscription)
+		}
+	}
+	return filtered
+}
+
+// TotalAmount sums the subscription amounts per currency code. Amounts in
+// different currencies are never added together; use BaseClient.NormalizeAmount to
+// convert them first if a single total is needed.
+func (s Subscriptions) TotalAmount() map[string]Money {
+	totals := make(map[string]Money)
+	for _, subscription := range s {
+		totals[strings.ToUpper(subscription.Currency)] += subscription.Amount
+	}
+	return totals
+}
+
+// GroupByPlan returns the subscriptions keyed by plan, keeping their order
+// within each plan
+func (s Subscriptions) GroupByPlan() map[string][]Subscription {
+	groups := make(map[string][]Subscription)
+	for _, subscription := range s {
+		groups[subscription.Plan] = append(groups[subscription.Plan], subscription)
+	}
+	return groups
+}
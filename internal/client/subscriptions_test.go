@@ -0,0 +1,52 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+var mixedSubscriptions = Subscriptions{
+	{ID: "S1", Status: StatusActive, Plan: "pro", Amount: Money(1999), Currency: "EUR"},
+	{ID: "S2", Status: StatusCancelled, Plan: "basic", Amount: Money(500), Currency: "usd"},
+	{ID: "S3", Status: StatusActive, Plan: "pro", Amount: Money(2001), Currency: "eur"},
+	{ID: "S4", Status: StatusActive, Plan: "basic", Amount: Money(50000), Currency: "JPY"},
+	{ID: "S5", Status: StatusActive, Plan: "pro", Amount: Money(1000), Currency: "USD"},
+}
+
+func TestTotalAmountMixedCurrencies(t *testing.T) {
+	want := map[string]Money{
+		"EUR": Money(4000),
+		"USD": Money(1500),
+		"JPY": Money(50000),
+	}
+	if got := mixedSubscriptions.TotalAmount(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TotalAmount() = %v, want %v", got, want)
+	}
+
+	if got := (Subscriptions{}).TotalAmount(); len(got) != 0 {
+		t.Errorf("TotalAmount() of no subscriptions = %v, want empty", got)
+	}
+}
+
+func TestFilterByStatus(t *testing.T) {
+	active := mixedSubscriptions.FilterByStatus(StatusActive)
+	if got, want := subscriptionIDs(active), []string{"S1", "S3", "S4", "S5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterByStatus(active) = %v, want %v", got, want)
+	}
+	if got := mixedSubscriptions.FilterByStatus(StatusExpired); len(got) != 0 {
+		t.Errorf("FilterByStatus(expired) = %v, want none", got)
+	}
+}
+
+func TestGroupByPlan(t *testing.T) {
+	groups := mixedSubscriptions.GroupByPlan()
+	if len(groups) != 2 {
+		t.Fatalf("GroupByPlan() returned %d plans, want 2", len(groups))
+	}
+	if got, want := subscriptionIDs(groups["pro"]), []string{"S1", "S3", "S5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pro = %v, want %v", got, want)
+	}
+	if got, want := subscriptionIDs(groups["basic"]), []string{"S2", "S4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("basic = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import "fmt"
+
+// SubscriptionView selects which terms of a subscription GetSubscription returns
+type SubscriptionView string
+
+const (
+	// SubscriptionViewCurrent returns the subscription as it stands today: the
+	// current plan, quantity and price, including upgrades, downgrades and price
+	// changes made since purchase.
+	SubscriptionViewCurrent SubscriptionView = "current"
+
+	// SubscriptionViewOriginal returns the terms the subscription was originally
+	// purchased with. Later plan, quantity and price changes are not reflected;
+	// status and renewal dates are always current.
+	SubscriptionViewOriginal SubscriptionView = "original"
+)
+
+// isCurrent maps the view to the API's isCurrent flag
+func (v SubscriptionView) isCurrent() (bool, error) {
+	switch v {
+	case SubscriptionViewCurrent:
+		return true, nil
+	case SubscriptionViewOriginal:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid subscription view %q", string(v))
+	}
+}
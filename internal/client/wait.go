@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// WaitForStatus polls a subscription until it reaches the target status, e.g.
+// after a plan change that is processed in the background. Polls are spaced
+// poll apart with ±50% jitter so that many waiters don't hit the API in step.
+// It stops when ctx is done, returning the last subscription seen along with
+// an error wrapping ctx.Err(); a failed poll ends the wait with that error.
+func (c *BaseClient) WaitForStatus(ctx context.Context, subscriptionID string, target SubscriptionStatus, poll time.Duration) (*Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+	if poll <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive")
+	}
+
+	c.log(ctx).Info("Waiting for subscription status",
+		"subscription_id", subscriptionID,
+		"target_status", target,
+		"poll", poll.String())
+
+	var last *Subscription
+	for {
+		subscription, err := c.GetSubscription(ctx, subscriptionID, SubscriptionViewCurrent)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, fmt.Errorf("subscription %s did not reach status %s: %w", subscriptionID, target, ctx.Err())
+			}
+			return last, err
+		}
+		last = subscription
+		if subscription.Status == target {
+			c.log(ctx).Info("Subscription reached status",
+				"subscription_id", subscriptionID,
+				"status", subscription.Status)
+			return subscription, nil
+		}
+
+		delay := poll/2 + time.Duration(rand.Int63n(int64(poll)+1))
+		if err := c.clock.Sleep(ctx, delay); err != nil {
+			return last, fmt.Errorf("subscription %s did not reach status %s: %w", subscriptionID, target, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Notification is a Cleverbridge event, delivered as a webhook or listed with
+// ListNotifications
+type Notification struct {
+	ID             string `json:"notificationId"`
+	Type           string `json:"eventType"`
+	SubscriptionID string `json:"subscriptionId"`
+	PurchaseID     string `json:"purchaseId"`
+	CustomerID     string `json:"customerId"`
+	CreatedAt      CBTime `json:"createdAt"`
+	// Payload is the event-specific data, when the notification carries it
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// Raw is the complete payload, for fields not mapped above
+	Raw json.RawMessage `json:"-"`
+}
+
+// VerifyWebhookSignature checks that signatureHeader is the HMAC-SHA256 of payload
+// keyed with secret. The signature may be hex or base64 encoded and may carry a
+// "sha256=" prefix. Pass the raw request body, before any JSON decoding. A
+// mismatch returns an error matching ErrInvalidSignature.
+func VerifyWebhookSignature(payload []byte, signatureHeader string, secret string) error {
+	if secret == "" {
+		return fmt.Errorf("webhook secret is required")
+	}
+
+	signature := strings.TrimSpace(signatureHeader)
+	if prefix, value, ok := strings.Cut(signature, "="); ok && strings.EqualFold(prefix, "sha256") {
+		signature = value
+	}
+	if signature == "" {
+		return fmt.Errorf("missing signature: %w", ErrInvalidSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		got, err = base64.StdEncoding.DecodeString(signature)
+	}
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", ErrInvalidSignature)
+	}
+
+	if !hmac.Equal(got, expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseNotification decodes a webhook payload. Verify the signature with
+// VerifyWebhookSignature first.
+func ParseNotification(payload []byte) (*Notification, error) {
+	if isEmptyBody(payload) {
+		return nil, fmt.Errorf("notification payload is empty")
+	}
+
+	var notification Notification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, fmt.Errorf("failed to parse notification: %w", err)
+	}
+	if notification.Type == "" {
+		return nil, fmt.Errorf("notification has no event type")
+	}
+	notification.Raw = append(json.RawMessage(nil), payload...)
+
+	return &notification, nil
+}